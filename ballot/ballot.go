@@ -0,0 +1,314 @@
+package ballot
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ednesic/vote-test/elgamal"
+	"github.com/ednesic/vote-test/pb"
+)
+
+const (
+	// encrypted is the shape of ballots holding only an encrypted choice vector
+	encrypted pb.VotingMethod = -1
+	// answers is the shape of ballots answering the contests of a referendum
+	answers pb.VotingMethod = -2
+	// abstain is the shape of blank ballots
+	abstain pb.VotingMethod = -3
+)
+
+var (
+	// ErrEmpty is returned for ballots without any choice
+	ErrEmpty = errors.New("ballot has no choice")
+	// ErrMixed is returned for ballots filled in more than one shape
+	ErrMixed = errors.New("ballot mixes several shapes")
+	// ErrMethod is returned when the ballot shape does not match the voting method
+	ErrMethod = errors.New("ballot shape does not match the voting method")
+	// ErrUnknownCandidate is returned when a choice is not a candidate
+	ErrUnknownCandidate = errors.New("candidate not found")
+	// ErrDuplicate is returned when a candidate is chosen more than once
+	ErrDuplicate = errors.New("candidate chosen more than once")
+	// ErrScore is returned for scores outside 0 to the max score
+	ErrScore = errors.New("invalid score")
+	// ErrPlaintext is returned for plaintext ballots on secret elections
+	ErrPlaintext = errors.New("secret election does not accept plaintext ballots")
+	// ErrEncrypted is returned for encrypted ballots on open elections
+	ErrEncrypted = errors.New("election does not accept encrypted ballots")
+	// ErrUnknownContest is returned when an answer is not for a contest of the election
+	ErrUnknownContest = errors.New("contest not found")
+	// ErrDuplicateContest is returned when a contest is answered more than once
+	ErrDuplicateContest = errors.New("contest answered more than once")
+	// ErrAbstain is returned for blank ballots on elections not allowing abstentions
+	ErrAbstain = errors.New("election does not accept blank ballots")
+	// ErrWithdrawn is returned when a choice is a withdrawn candidate
+	ErrWithdrawn = errors.New("candidate withdrawn")
+)
+
+// Check makes sure the ballot is filled in exactly one shape
+func Check(v *pb.Vote) error {
+	_, err := shape(v)
+	return err
+}
+
+// Validate checks the ballot against the voting method and candidates of the
+// election
+func Validate(e *pb.Election, v *pb.Vote) error {
+	method, err := shape(v)
+	if err != nil {
+		return err
+	}
+
+	if e.GetSecret() {
+		if method != encrypted {
+			return ErrPlaintext
+		}
+		return validateEncrypted(e, v)
+	}
+	if method == encrypted {
+		return ErrEncrypted
+	}
+	if method == abstain {
+		if !e.GetAbstain() {
+			return ErrAbstain
+		}
+		return nil
+	}
+	if len(e.GetContests()) > 0 || method == answers {
+		if method != answers || len(e.GetContests()) == 0 {
+			return ErrMethod
+		}
+		return validateAnswers(e, v.GetAnswers())
+	}
+	if method != shapeOf(e.GetVotingMethod()) {
+		return ErrMethod
+	}
+
+	switch method {
+	case pb.VotingMethod_PLURALITY:
+		return validateChoices(e, []string{v.GetCandidate()})
+	case pb.VotingMethod_APPROVAL:
+		return validateChoices(e, v.GetSelections())
+	case pb.VotingMethod_RANKED:
+		return validateChoices(e, v.GetRankings())
+	case pb.VotingMethod_SCORE:
+		return validateScores(e, v.GetScores())
+	}
+	return ErrMethod
+}
+
+// shapeOf returns the ballot shape used by a voting method
+func shapeOf(m pb.VotingMethod) pb.VotingMethod {
+	switch m {
+	case pb.VotingMethod_SCHULZE, pb.VotingMethod_STV:
+		return pb.VotingMethod_RANKED
+	case pb.VotingMethod_STAR:
+		return pb.VotingMethod_SCORE
+	}
+	return m
+}
+
+func shape(v *pb.Vote) (pb.VotingMethod, error) {
+	var (
+		method pb.VotingMethod
+		shapes int
+	)
+	if v.GetCandidate() != "" {
+		method, shapes = pb.VotingMethod_PLURALITY, shapes+1
+	}
+	if len(v.GetSelections()) > 0 {
+		method, shapes = pb.VotingMethod_APPROVAL, shapes+1
+	}
+	if len(v.GetRankings()) > 0 {
+		method, shapes = pb.VotingMethod_RANKED, shapes+1
+	}
+	if len(v.GetScores()) > 0 {
+		method, shapes = pb.VotingMethod_SCORE, shapes+1
+	}
+	if len(v.GetEncrypted()) > 0 {
+		method, shapes = encrypted, shapes+1
+	}
+	if len(v.GetAnswers()) > 0 {
+		method, shapes = answers, shapes+1
+	}
+	if v.GetAbstain() {
+		method, shapes = abstain, shapes+1
+	}
+
+	switch shapes {
+	case 0:
+		return method, ErrEmpty
+	case 1:
+		return method, nil
+	}
+	return method, ErrMixed
+}
+
+// Contest returns the contest as an election of its own, so its answers
+// validate and count like ballots
+func Contest(c *pb.Contest) *pb.Election {
+	return &pb.Election{
+		Candidates:   c.GetCandidates(),
+		VotingMethod: c.GetVotingMethod(),
+		Seats:        c.GetSeats(),
+		MaxScore:     c.GetMaxScore(),
+		WriteIns:     c.GetWriteIns(),
+		Abstain:      c.GetAbstain(),
+		Withdrawn:    c.GetWithdrawn(),
+	}
+}
+
+// Answer returns the answer as a ballot of its own carrying the weight of the
+// whole ballot
+func Answer(a *pb.Answer, weight int64) pb.Vote {
+	return pb.Vote{
+		Candidate:  a.GetCandidate(),
+		Selections: a.GetSelections(),
+		Rankings:   a.GetRankings(),
+		Scores:     a.GetScores(),
+		Abstain:    a.GetAbstain(),
+		Weight:     weight,
+	}
+}
+
+// validateAnswers checks every answer against its contest, contests left
+// unanswered are fine
+func validateAnswers(e *pb.Election, as []*pb.Answer) error {
+	contests := make(map[string]*pb.Contest, len(e.GetContests()))
+	for _, c := range e.GetContests() {
+		contests[c.GetId()] = c
+	}
+
+	seen := make(map[string]bool, len(as))
+	for _, a := range as {
+		c, ok := contests[a.GetContest()]
+		if !ok {
+			return ErrUnknownContest
+		}
+		if seen[a.GetContest()] {
+			return ErrDuplicateContest
+		}
+		seen[a.GetContest()] = true
+
+		v := Answer(a, 0)
+		err := Validate(Contest(c), &v)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Normalize folds case and white space of a write-in, so spellings of the
+// same name group together
+func Normalize(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// Resolve returns the candidate a choice counts for. On elections allowing
+// write-ins a choice spelling a candidate id or name resolves to the
+// candidate, any other choice to its normalized form. Otherwise a choice
+// matching no candidate resolves to an empty string.
+func Resolve(e *pb.Election, choice string) string {
+	for _, c := range e.GetCandidates() {
+		if c == choice {
+			return c
+		}
+	}
+	if !e.GetWriteIns() {
+		return ""
+	}
+
+	name := Normalize(choice)
+	for _, c := range e.GetCandidates() {
+		if Normalize(c) == name {
+			return c
+		}
+	}
+	for _, d := range e.GetDetails() {
+		if Normalize(d.GetName()) == name {
+			return d.GetId()
+		}
+	}
+	return name
+}
+
+// Details returns the details of the candidate with the id, nil if the
+// election has none
+func Details(e *pb.Election, id string) *pb.Candidate {
+	for _, d := range e.GetDetails() {
+		if d.GetId() == id {
+			return d
+		}
+	}
+	return nil
+}
+
+// Withdrawn tells whether the candidate withdrew from the election
+func Withdrawn(e *pb.Election, candidate string) bool {
+	for _, c := range e.GetWithdrawn() {
+		if c == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+func validateChoices(e *pb.Election, choices []string) error {
+	seen := make(map[string]bool, len(choices))
+	for _, c := range choices {
+		r := Resolve(e, c)
+		if r == "" {
+			return ErrUnknownCandidate
+		}
+		if Withdrawn(e, r) {
+			return ErrWithdrawn
+		}
+		if seen[r] {
+			return ErrDuplicate
+		}
+		seen[r] = true
+	}
+	return nil
+}
+
+func validateScores(e *pb.Election, scores map[string]int32) error {
+	seen := make(map[string]bool, len(scores))
+	for c, score := range scores {
+		r := Resolve(e, c)
+		if r == "" {
+			return ErrUnknownCandidate
+		}
+		if seen[r] {
+			return ErrDuplicate
+		}
+		seen[r] = true
+		if score < 0 || score > e.GetMaxScore() {
+			return ErrScore
+		}
+		// a zero score does not choose the candidate
+		if score > 0 && Withdrawn(e, r) {
+			return ErrWithdrawn
+		}
+	}
+	return nil
+}
+
+// validateEncrypted checks the proofs that every encrypted choice is 0 or 1
+// and, on plurality elections, that exactly one candidate is chosen
+func validateEncrypted(e *pb.Election, v *pb.Vote) error {
+	if len(v.GetEncrypted()) != len(e.GetCandidates()) || len(v.GetChoiceProofs()) != len(v.GetEncrypted()) {
+		return elgamal.ErrLengthMismatch
+	}
+	for i, c := range v.GetEncrypted() {
+		if !elgamal.Valid(c) {
+			return elgamal.ErrInvalidCiphertext
+		}
+		if !elgamal.VerifyChoice(e.GetEncryptionKey(), c, v.GetChoiceProofs()[i]) {
+			return elgamal.ErrInvalidProof
+		}
+	}
+	if e.GetVotingMethod() == pb.VotingMethod_PLURALITY && !elgamal.VerifySum(e.GetEncryptionKey(), v.GetEncrypted(), 1, v.GetSumProof()) {
+		return elgamal.ErrInvalidProof
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package ballot
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/ednesic/vote-test/elgamal"
+	"github.com/ednesic/vote-test/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_check(t *testing.T) {
+	tests := []struct {
+		name    string
+		vote    *pb.Vote
+		wantErr error
+	}{
+		{"Candidate", &pb.Vote{Candidate: "test1"}, nil},
+		{"Selections", &pb.Vote{Selections: []string{"test1", "test2"}}, nil},
+		{"Rankings", &pb.Vote{Rankings: []string{"test2", "test1"}}, nil},
+		{"Scores", &pb.Vote{Scores: map[string]int32{"test1": 5}}, nil},
+		{"Encrypted", &pb.Vote{Encrypted: []*pb.Ciphertext{{}}}, nil},
+		{"Answers", &pb.Vote{Answers: []*pb.Answer{{Contest: "q1", Candidate: "yes"}}}, nil},
+		{"Abstain", &pb.Vote{Abstain: true}, nil},
+		{"Candidate and abstain", &pb.Vote{Candidate: "test1", Abstain: true}, ErrMixed},
+		{"Candidate and answers", &pb.Vote{Candidate: "test1", Answers: []*pb.Answer{{Contest: "q1", Candidate: "yes"}}}, ErrMixed},
+		{"Empty", &pb.Vote{ElectionId: 1}, ErrEmpty},
+		{"Candidate and selections", &pb.Vote{Candidate: "test1", Selections: []string{"test1"}}, ErrMixed},
+		{"Rankings and scores", &pb.Vote{Rankings: []string{"test1"}, Scores: map[string]int32{"test1": 5}}, ErrMixed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Check(tt.vote); err != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_normalize(t *testing.T) {
+	assert.Equal(t, "jane doe", Normalize("  Jane \t DOE "))
+	assert.Equal(t, "", Normalize("  "))
+}
+
+func Test_validate(t *testing.T) {
+	key, _ := elgamal.DeriveKey(make([]byte, 32), 1)
+	one, _ := elgamal.Encrypt(rand.Reader, key.Public(), 1)
+	zero, _ := elgamal.Encrypt(rand.Reader, key.Public(), 0)
+	choice, proofs, sum, _ := elgamal.EncryptChoices(rand.Reader, key.Public(), []int64{1, 0})
+	both, bothProofs, bothSum, _ := elgamal.EncryptChoices(rand.Reader, key.Public(), []int64{1, 1})
+
+	candidates := []string{"test1", "test2"}
+	plurality := &pb.Election{Id: 1, Candidates: candidates}
+	approval := &pb.Election{Id: 1, Candidates: candidates, VotingMethod: pb.VotingMethod_APPROVAL}
+	ranked := &pb.Election{Id: 1, Candidates: candidates, VotingMethod: pb.VotingMethod_RANKED}
+	schulze := &pb.Election{Id: 1, Candidates: candidates, VotingMethod: pb.VotingMethod_SCHULZE}
+	score := &pb.Election{Id: 1, Candidates: candidates, VotingMethod: pb.VotingMethod_SCORE, MaxScore: 5}
+	star := &pb.Election{Id: 1, Candidates: candidates, VotingMethod: pb.VotingMethod_STAR, MaxScore: 5}
+	secret := &pb.Election{Id: 1, Secret: true, Candidates: candidates, EncryptionKey: key.Public()}
+	secretApproval := &pb.Election{Id: 1, Secret: true, Candidates: candidates, EncryptionKey: key.Public(), VotingMethod: pb.VotingMethod_APPROVAL}
+	writeIns := &pb.Election{Id: 1, Candidates: candidates, VotingMethod: pb.VotingMethod_RANKED, WriteIns: true}
+	blank := &pb.Election{Id: 1, Candidates: candidates, Abstain: true}
+	withdrawn := &pb.Election{Id: 1, Candidates: candidates, VotingMethod: pb.VotingMethod_SCORE, MaxScore: 5, Withdrawn: []string{"test2"}, WriteIns: true}
+	referendum := &pb.Election{Id: 1, Contests: []*pb.Contest{
+		{Id: "q1", Candidates: []string{"yes", "no"}, Abstain: true},
+		{Id: "q2", Candidates: candidates, VotingMethod: pb.VotingMethod_RANKED},
+	}}
+
+	tests := []struct {
+		name     string
+		election *pb.Election
+		vote     *pb.Vote
+		wantErr  error
+	}{
+		{"Plurality", plurality, &pb.Vote{Candidate: "test1"}, nil},
+		{"Plurality unknown candidate", plurality, &pb.Vote{Candidate: "test3"}, ErrUnknownCandidate},
+		{"Plurality with selections", plurality, &pb.Vote{Selections: []string{"test1"}}, ErrMethod},
+		{"Approval", approval, &pb.Vote{Selections: []string{"test1", "test2"}}, nil},
+		{"Approval duplicate", approval, &pb.Vote{Selections: []string{"test1", "test1"}}, ErrDuplicate},
+		{"Approval with candidate", approval, &pb.Vote{Candidate: "test1"}, ErrMethod},
+		{"Ranked", ranked, &pb.Vote{Rankings: []string{"test2", "test1"}}, nil},
+		{"Ranked partial", ranked, &pb.Vote{Rankings: []string{"test2"}}, nil},
+		{"Ranked unknown candidate", ranked, &pb.Vote{Rankings: []string{"test2", "test3"}}, ErrUnknownCandidate},
+		{"Ranked duplicate", ranked, &pb.Vote{Rankings: []string{"test2", "test2"}}, ErrDuplicate},
+		{"Schulze", schulze, &pb.Vote{Rankings: []string{"test2", "test1"}}, nil},
+		{"Schulze with candidate", schulze, &pb.Vote{Candidate: "test1"}, ErrMethod},
+		{"Score", score, &pb.Vote{Scores: map[string]int32{"test1": 5, "test2": 0}}, nil},
+		{"Score unknown candidate", score, &pb.Vote{Scores: map[string]int32{"test3": 5}}, ErrUnknownCandidate},
+		{"Negative score", score, &pb.Vote{Scores: map[string]int32{"test1": -1}}, ErrScore},
+		{"Score above max", score, &pb.Vote{Scores: map[string]int32{"test1": 6}}, ErrScore},
+		{"STAR", star, &pb.Vote{Scores: map[string]int32{"test1": 5, "test2": 2}}, nil},
+		{"STAR with rankings", star, &pb.Vote{Rankings: []string{"test1"}}, ErrMethod},
+		{"Empty", score, &pb.Vote{}, ErrEmpty},
+		{"Referendum", referendum, &pb.Vote{Answers: []*pb.Answer{{Contest: "q1", Candidate: "yes"}, {Contest: "q2", Rankings: []string{"test2", "test1"}}}}, nil},
+		{"Referendum with unanswered contest", referendum, &pb.Vote{Answers: []*pb.Answer{{Contest: "q2", Rankings: []string{"test1"}}}}, nil},
+		{"Referendum unknown contest", referendum, &pb.Vote{Answers: []*pb.Answer{{Contest: "q3", Candidate: "yes"}}}, ErrUnknownContest},
+		{"Referendum contest answered twice", referendum, &pb.Vote{Answers: []*pb.Answer{{Contest: "q1", Candidate: "yes"}, {Contest: "q1", Candidate: "no"}}}, ErrDuplicateContest},
+		{"Referendum answer of wrong shape", referendum, &pb.Vote{Answers: []*pb.Answer{{Contest: "q2", Candidate: "test1"}}}, ErrMethod},
+		{"Referendum empty answer", referendum, &pb.Vote{Answers: []*pb.Answer{{Contest: "q1"}}}, ErrEmpty},
+		{"Referendum unknown option", referendum, &pb.Vote{Answers: []*pb.Answer{{Contest: "q1", Candidate: "maybe"}}}, ErrUnknownCandidate},
+		{"Referendum abstain on contest", referendum, &pb.Vote{Answers: []*pb.Answer{{Contest: "q1", Abstain: true}}}, nil},
+		{"Referendum abstain on contest without abstentions", referendum, &pb.Vote{Answers: []*pb.Answer{{Contest: "q2", Abstain: true}}}, ErrAbstain},
+		{"Referendum withdrawn option", &pb.Election{Id: 1, Contests: []*pb.Contest{{Id: "q1", Candidates: []string{"yes", "no", "maybe"}, Withdrawn: []string{"maybe"}}}}, &pb.Vote{Answers: []*pb.Answer{{Contest: "q1", Candidate: "maybe"}}}, ErrWithdrawn},
+		{"Referendum without answers", referendum, &pb.Vote{Candidate: "yes"}, ErrMethod},
+		{"Answers on single question", plurality, &pb.Vote{Answers: []*pb.Answer{{Contest: "q1", Candidate: "yes"}}}, ErrMethod},
+		{"Abstain", blank, &pb.Vote{Abstain: true}, nil},
+		{"Abstain without abstentions", plurality, &pb.Vote{Abstain: true}, ErrAbstain},
+		{"Write-in", writeIns, &pb.Vote{Rankings: []string{" Jane  Doe ", "test1"}}, nil},
+		{"Write-in matching a candidate", writeIns, &pb.Vote{Rankings: []string{"TEST2"}}, nil},
+		{"Write-in of a name and its id", &pb.Election{Candidates: []string{"c1"}, Details: []*pb.Candidate{{Id: "c1", Name: "Jane Doe"}}, VotingMethod: pb.VotingMethod_RANKED, WriteIns: true}, &pb.Vote{Rankings: []string{"jane doe", "c1"}}, ErrDuplicate},
+		{"Name without write-ins", &pb.Election{Candidates: []string{"c1"}, Details: []*pb.Candidate{{Id: "c1", Name: "Jane Doe"}}, VotingMethod: pb.VotingMethod_RANKED}, &pb.Vote{Rankings: []string{"Jane Doe"}}, ErrUnknownCandidate},
+		{"Write-in spelled twice", writeIns, &pb.Vote{Rankings: []string{"Jane Doe", "jane doe"}}, ErrDuplicate},
+		{"Write-in and its candidate", writeIns, &pb.Vote{Rankings: []string{"test2", "Test2"}}, ErrDuplicate},
+		{"Blank write-in", writeIns, &pb.Vote{Rankings: []string{"  "}}, ErrUnknownCandidate},
+		{"Write-in without write-ins", ranked, &pb.Vote{Rankings: []string{"TEST2"}}, ErrUnknownCandidate},
+		{"Withdrawn candidate", withdrawn, &pb.Vote{Scores: map[string]int32{"test1": 1, "test2": 3}}, ErrWithdrawn},
+		{"Withdrawn candidate written in", withdrawn, &pb.Vote{Scores: map[string]int32{"TEST2": 3}}, ErrWithdrawn},
+		{"Zero score for withdrawn candidate", withdrawn, &pb.Vote{Scores: map[string]int32{"test1": 1, "test2": 0}}, nil},
+		{"Withdrawn candidate ranked", &pb.Election{Candidates: candidates, VotingMethod: pb.VotingMethod_RANKED, Withdrawn: []string{"test2"}}, &pb.Vote{Rankings: []string{"test1", "test2"}}, ErrWithdrawn},
+		{"Encrypted on open election", plurality, &pb.Vote{Encrypted: []*pb.Ciphertext{one, zero}}, ErrEncrypted},
+		{"Encrypted on secret election", secret, &pb.Vote{Encrypted: choice, ChoiceProofs: proofs, SumProof: sum}, nil},
+		{"Plaintext on secret election", secret, &pb.Vote{Candidate: "test1"}, ErrPlaintext},
+		{"Vector shorter than candidates", secret, &pb.Vote{Encrypted: choice[:1], ChoiceProofs: proofs[:1], SumProof: sum}, elgamal.ErrLengthMismatch},
+		{"Invalid ciphertext", secret, &pb.Vote{Encrypted: []*pb.Ciphertext{{A: []byte{0}, B: []byte{0}}, one}, ChoiceProofs: proofs, SumProof: sum}, elgamal.ErrInvalidCiphertext},
+		{"Encrypted without proofs", secret, &pb.Vote{Encrypted: []*pb.Ciphertext{one, zero}}, elgamal.ErrLengthMismatch},
+		{"Proofs of other ciphertexts", secret, &pb.Vote{Encrypted: []*pb.Ciphertext{one, zero}, ChoiceProofs: proofs, SumProof: sum}, elgamal.ErrInvalidProof},
+		{"Two choices on plurality", secret, &pb.Vote{Encrypted: both, ChoiceProofs: bothProofs, SumProof: bothSum}, elgamal.ErrInvalidProof},
+		{"Plurality without sum proof", secret, &pb.Vote{Encrypted: choice, ChoiceProofs: proofs}, elgamal.ErrInvalidProof},
+		{"Two choices on approval", secretApproval, &pb.Vote{Encrypted: both, ChoiceProofs: bothProofs}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.election, tt.vote); err != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package ballot
+
+import "github.com/ednesic/vote-test/pb"
+
+// Localize puts the texts of a ballot in the language of locale, the labels
+// of the election for that locale replace the title, description, candidate
+// names and questions they translate and label the options they name
+func Localize(b *pb.Ballot, e *pb.Election, locale string) {
+	b.Locale = locale
+	l := e.GetLabels()[locale]
+	if l == nil {
+		return
+	}
+
+	if l.GetTitle() != "" {
+		b.Title = l.GetTitle()
+	}
+	if l.GetDescription() != "" {
+		b.Description = l.GetDescription()
+	}
+	for i, d := range b.GetDetails() {
+		if name := l.GetCandidates()[d.GetId()]; name != "" {
+			b.Details[i] = &pb.Candidate{Id: d.GetId(), Name: name, Description: d.GetDescription(), Metadata: d.GetMetadata()}
+		}
+	}
+	for _, c := range b.GetContests() {
+		if q := l.GetQuestions()[c.GetContest()]; q != "" {
+			c.Question = q
+		}
+		names := l.GetOptions()[c.GetContest()].GetNames()
+		if len(names) == 0 {
+			continue
+		}
+		c.Labels = make([]string, len(c.GetCandidates()))
+		for i, o := range c.GetCandidates() {
+			c.Labels[i] = o
+			if name := names[o]; name != "" {
+				c.Labels[i] = name
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package ballot
+
+import (
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_localize(t *testing.T) {
+	e := &pb.Election{
+		Id:          1,
+		Title:       "Board election",
+		Description: "Elect the board",
+		Candidates:  []string{"c1", "c2"},
+		Details:     []*pb.Candidate{{Id: "c1", Name: "Jane", Description: "Treasurer"}, {Id: "c2", Name: "John"}},
+		Contests:    []*pb.Contest{{Id: "q1", Question: "Approve the budget?", Candidates: []string{"yes", "no"}}},
+		Locale:      "en",
+		Labels: map[string]*pb.Labels{
+			"pt-BR": {Title: "Eleição da diretoria", Candidates: map[string]string{"c1": "Joana"}, Questions: map[string]string{"q1": "Aprovar o orçamento?"},
+				Options: map[string]*pb.OptionLabels{"q1": {Names: map[string]string{"yes": "Sim"}}}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		locale      string
+		title       string
+		description string
+		names       []string
+		question    string
+		options     []string
+	}{
+		{"Default locale", "en", "Board election", "Elect the board", []string{"Jane", "John"}, "Approve the budget?", nil},
+		{"Translated", "pt-BR", "Eleição da diretoria", "Elect the board", []string{"Joana", "John"}, "Aprovar o orçamento?", []string{"Sim", "no"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := Order(e, "voter")
+			Localize(b, e, tt.locale)
+			assert.Equal(t, tt.locale, b.GetLocale())
+			assert.Equal(t, tt.title, b.GetTitle())
+			assert.Equal(t, tt.description, b.GetDescription())
+			assert.Equal(t, tt.names, []string{b.GetDetails()[0].GetName(), b.GetDetails()[1].GetName()})
+			assert.Equal(t, "Treasurer", b.GetDetails()[0].GetDescription())
+			assert.Equal(t, tt.question, b.GetContests()[0].GetQuestion())
+			assert.Equal(t, tt.options, b.GetContests()[0].GetLabels())
+		})
+	}
+	assert.Equal(t, "Jane", e.GetDetails()[0].GetName())
+}
@@ -0,0 +1,70 @@
+package ballot
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sort"
+
+	"github.com/ednesic/vote-test/pb"
+)
+
+// PerVoter tells whether the ballot order of the election depends on the voter
+func PerVoter(e *pb.Election) bool {
+	return e.GetBallotOrder() == pb.BallotOrder_SHUFFLE || e.GetBallotOrder() == pb.BallotOrder_ROTATION
+}
+
+// Order returns the ballot shown to a voter, with the candidates of the
+// election and of every contest in the ballot order of the election. Shuffles
+// and rotations are derived from the voter, so a voter always sees the same
+// ballot. Withdrawn candidates are left out and the details of the others
+// follow the same order.
+func Order(e *pb.Election, voter string) *pb.Ballot {
+	b := &pb.Ballot{
+		ElectionId:  e.GetId(),
+		Voter:       voter,
+		Title:       e.GetTitle(),
+		Description: e.GetDescription(),
+	}
+	for _, c := range order(e, e.GetCandidates(), voter) {
+		if Withdrawn(e, c) {
+			continue
+		}
+		b.Candidates = append(b.Candidates, c)
+		if d := Details(e, c); d != nil {
+			b.Details = append(b.Details, d)
+		}
+	}
+	for _, c := range e.GetContests() {
+		contest := &pb.ContestBallot{Contest: c.GetId(), Question: c.GetQuestion()}
+		for _, o := range order(e, c.GetCandidates(), voter) {
+			if !Withdrawn(Contest(c), o) {
+				contest.Candidates = append(contest.Candidates, o)
+			}
+		}
+		b.Contests = append(b.Contests, contest)
+	}
+	return b
+}
+
+func order(e *pb.Election, candidates []string, voter string) []string {
+	ordered := append([]string(nil), candidates...)
+	h := fnv.New64a()
+	h.Write([]byte(voter))
+	key := h.Sum64()
+
+	switch e.GetBallotOrder() {
+	case pb.BallotOrder_ALPHABETICAL:
+		sort.Strings(ordered)
+	case pb.BallotOrder_SHUFFLE:
+		r := rand.New(rand.NewSource(e.GetBallotSeed() ^ int64(key)))
+		r.Shuffle(len(ordered), func(i, j int) {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		})
+	case pb.BallotOrder_ROTATION:
+		if len(ordered) > 0 {
+			k := int(key % uint64(len(ordered)))
+			ordered = append(ordered[k:], ordered[:k]...)
+		}
+	}
+	return ordered
+}
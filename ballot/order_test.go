@@ -0,0 +1,76 @@
+package ballot
+
+import (
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_order(t *testing.T) {
+	candidates := []string{"c", "a", "d", "b"}
+
+	tests := []struct {
+		name  string
+		order pb.BallotOrder
+		want  []string
+	}{
+		{"Fixed", pb.BallotOrder_FIXED, candidates},
+		{"Alphabetical", pb.BallotOrder_ALPHABETICAL, []string{"a", "b", "c", "d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &pb.Election{Id: 1, Candidates: candidates, BallotOrder: tt.order}
+			assert.Equal(t, tt.want, Order(e, "voter").GetCandidates())
+			assert.False(t, PerVoter(e))
+		})
+	}
+
+	for _, o := range []pb.BallotOrder{pb.BallotOrder_SHUFFLE, pb.BallotOrder_ROTATION} {
+		t.Run(o.String(), func(t *testing.T) {
+			e := &pb.Election{Id: 1, Candidates: candidates, BallotOrder: o, BallotSeed: 42}
+			b := Order(e, "voter")
+			assert.True(t, PerVoter(e))
+			assert.ElementsMatch(t, candidates, b.GetCandidates())
+			assert.Equal(t, b, Order(e, "voter"))
+			assert.Equal(t, []string{"c", "a", "d", "b"}, candidates)
+
+			// some voter among a few sees another order
+			differs := false
+			for _, v := range []string{"v1", "v2", "v3", "v4", "v5", "v6"} {
+				differs = differs || !assert.ObjectsAreEqual(b.GetCandidates(), Order(e, v).GetCandidates())
+			}
+			assert.True(t, differs)
+		})
+	}
+
+	t.Run("Rotation keeps the cycle", func(t *testing.T) {
+		e := &pb.Election{Candidates: candidates, BallotOrder: pb.BallotOrder_ROTATION}
+		got := Order(e, "voter").GetCandidates()
+		for i := range got {
+			if got[i] == candidates[0] {
+				assert.Equal(t, candidates, append(got[i:], got[:i]...))
+			}
+		}
+	})
+
+	t.Run("Withdrawn candidates", func(t *testing.T) {
+		e := &pb.Election{Candidates: candidates, BallotOrder: pb.BallotOrder_ALPHABETICAL, Withdrawn: []string{"b"}}
+		assert.Equal(t, []string{"a", "c", "d"}, Order(e, "voter").GetCandidates())
+	})
+
+	t.Run("Withdrawn options", func(t *testing.T) {
+		e := &pb.Election{Id: 1, Contests: []*pb.Contest{{Id: "q1", Candidates: []string{"x", "y", "z"}, Withdrawn: []string{"y"}}}}
+		assert.Equal(t, []*pb.ContestBallot{{Contest: "q1", Candidates: []string{"x", "z"}}}, Order(e, "").GetContests())
+	})
+
+	t.Run("Details", func(t *testing.T) {
+		e := &pb.Election{Candidates: []string{"c2", "c1"}, BallotOrder: pb.BallotOrder_ALPHABETICAL, Details: []*pb.Candidate{{Id: "c2", Name: "Ann"}, {Id: "c1", Name: "Bob"}}}
+		assert.Equal(t, []*pb.Candidate{{Id: "c1", Name: "Bob"}, {Id: "c2", Name: "Ann"}}, Order(e, "").GetDetails())
+	})
+
+	t.Run("Contests", func(t *testing.T) {
+		e := &pb.Election{Id: 1, BallotOrder: pb.BallotOrder_ALPHABETICAL, Contests: []*pb.Contest{{Id: "q1", Candidates: []string{"yes", "no"}}}}
+		assert.Equal(t, []*pb.ContestBallot{{Contest: "q1", Candidates: []string{"no", "yes"}}}, Order(e, "").GetContests())
+	})
+}
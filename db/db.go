@@ -6,11 +6,16 @@ import (
 
 type DataAccessLayer interface {
 	Insert(collectionName string, docs interface{}) error
+	InsertAll(collName string, docs []interface{}) error
 	FindOne(collName string, query interface{}, doc interface{}) error
+	FindAll(collName string, query interface{}, docs interface{}) error
+	FindFirst(collName string, query interface{}, sort string, doc interface{}) error
 	Update(collName string, selector interface{}, update interface{}) error
 	Upsert(collName string, selector interface{}, update interface{}) error
 	Remove(collName string, selector interface{}) error
-	EnsureIndex(collName string, field string) error
+	EnsureIndex(collName string, unique bool, fields ...string) error
+	Ping() error
+	Close()
 }
 
 type MongoDAL struct {
@@ -35,6 +40,13 @@ func (m *MongoDAL) Insert(collName string, doc interface{}) error {
 	return session.DB(m.dbName).C(collName).Insert(doc)
 }
 
+// InsertAll stores documents in mongo in a single batch
+func (m *MongoDAL) InsertAll(collName string, docs []interface{}) error {
+	session := m.session.Clone()
+	defer session.Close()
+	return session.DB(m.dbName).C(collName).Insert(docs...)
+}
+
 // FindOne finds one document in mongo
 func (m *MongoDAL) FindOne(collName string, query interface{}, doc interface{}) error {
 	session := m.session.Clone()
@@ -42,6 +54,21 @@ func (m *MongoDAL) FindOne(collName string, query interface{}, doc interface{})
 	return session.DB(m.dbName).C(collName).Find(query).One(doc)
 }
 
+// FindAll finds every document in mongo matching the query
+func (m *MongoDAL) FindAll(collName string, query interface{}, docs interface{}) error {
+	session := m.session.Clone()
+	defer session.Close()
+	return session.DB(m.dbName).C(collName).Find(query).All(docs)
+}
+
+// FindFirst finds the first document in mongo matching the query in the
+// order of the sort field, prefixed with - for descending order
+func (m *MongoDAL) FindFirst(collName string, query interface{}, sort string, doc interface{}) error {
+	session := m.session.Clone()
+	defer session.Close()
+	return session.DB(m.dbName).C(collName).Find(query).Sort(sort).One(doc)
+}
+
 func (m *MongoDAL) Update(collName string, selector interface{}, update interface{}) error {
 	session := m.session.Clone()
 	defer session.Close()
@@ -62,15 +89,28 @@ func (m *MongoDAL) Remove(collName string, selector interface{}) error {
 	return err
 }
 
-func (m *MongoDAL) EnsureIndex(collName string, field string) error {
+// EnsureIndex creates an index over the fields, unique when asked to
+func (m *MongoDAL) EnsureIndex(collName string, unique bool, fields ...string) error {
 	session := m.session.Clone()
 	defer session.Close()
 	index := mgo.Index{
-        Key:    []string{field},
-        Unique: true,
+        Key:    fields,
+        Unique: unique,
     }
     if err := session.DB(m.dbName).C(collName).EnsureIndex(index); err != nil {
         return err
     }
 	return nil
-}
\ No newline at end of file
+}
+
+// Ping tells whether mongo answers
+func (m *MongoDAL) Ping() error {
+	session := m.session.Clone()
+	defer session.Close()
+	return session.Ping()
+}
+
+// Close closes the mongo session, operations in flight fail afterwards
+func (m *MongoDAL) Close() {
+	m.session.Close()
+}
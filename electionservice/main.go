@@ -1,20 +1,43 @@
 package main
 
 import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"sort"
 	"strconv"
+	"sync"
+	"syscall"
 	"time"
 
+	"github.com/ednesic/vote-test/ballot"
 	"github.com/ednesic/vote-test/db"
+	"github.com/ednesic/vote-test/elgamal"
+	"github.com/ednesic/vote-test/health"
+	"github.com/ednesic/vote-test/invite"
+	"github.com/ednesic/vote-test/merkle"
+	"github.com/ednesic/vote-test/metrics"
 	"github.com/ednesic/vote-test/pb"
+	"github.com/ednesic/vote-test/sign"
+	"github.com/ednesic/vote-test/tabulate"
+	"github.com/ednesic/vote-test/tracing"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/gorilla/mux"
 	"github.com/kelseyhightower/envconfig"
 	"go.uber.org/zap"
+	"golang.org/x/text/language"
 	mgo "gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -29,26 +52,131 @@ const (
 	errNotFound      = "Not found election"
 	errUpsert        = "Failed to insert/update election"
 	errInterrupt     = "Shutting down"
+	errListen        = "HTTP server failed"
+	errShutdown      = "Failed to shut down cleanly"
+	errTracing       = "Failed to set up tracing"
 	errEnsureIndex   = "Error in err Ensurance"
+	errNotOver       = "Election is not over"
+	errCommit        = "Failed to commit ballots"
+	errNotCommitted  = "Ballots are not committed yet"
+	errRootMismatch  = "Ballots do not match the committed root"
+	errSigningKey    = "Failed to load signing key"
+	errPublicKey     = "Failed to encode public key"
+	errResults       = "Failed to sign results"
+	errTally         = "Failed to tally ballots"
+	errElectionKey   = "Failed to get election key"
+	errInvalidCount  = "Invalid code count"
+	errNotInvite     = "Election is not invite only"
+	errCodes         = "Failed to create codes"
+	errInvalidBallot = "Invalid ballot"
+	errInvalidMethod = "Invalid voting method"
+	errSecretMethod  = "Secret elections only support plurality and approval voting"
+	errInvalidSeats  = "Seats must not exceed candidates, only STV elects more than one"
+	errMaxScore      = "Score and STAR elections need a positive max score"
+	errScoreLimit    = "Max score must not exceed 100"
+	errSecretWeight  = "Secret elections can not be weighted"
+	errNotWeighted   = "Election is not weighted"
+	errInvalidWeight = "Invalid voter weights"
+	errWeights       = "Failed to store voter weights"
+	errOver          = "Election is over"
+	errNotRanked     = "Election does not have ranked ballots"
+	errInvalidTopK   = "Invalid k"
+	errBadContest    = "Contests need a unique id and candidates"
+	errSecretContest = "Secret elections can not hold contests"
+	errSecretWriteIn = "Secret elections do not support write-ins or abstentions"
+	errInvalidRules  = "Invalid quorum or majority rules"
+	errMajority      = "Majority rules need plurality, approval or ranked voting"
+	errQuorumWeight  = "Weighted elections can not have a quorum percent of eligible voters"
+	errBadRunoff     = "Runoffs need a plurality election, a majority and a duration"
+	errRunoffVoters  = "Runoffs can not follow weighted or invite-only elections"
+	errInvalidTie    = "Invalid tie-break policy"
+	errSecretTie     = "Secret elections can not break ties by earliest vote"
+	errBadLot        = "Lot must list every candidate once"
+	errSeed          = "Failed to create tie-break seed"
+	errInvalidOrder  = "Invalid ballot order"
+	errNoVoter       = "Ballot order depends on the voter"
+	errWithdrawal    = "Withdrawn candidates must be candidates, redistribution needs ranked ballots"
+	errNoWithdraw    = "Secret elections can not withdraw candidates"
+	errCandidate     = "candidate not found"
+	errBadDetails    = "Candidate details need unique ids and names and must match the candidates"
+	errBadLabels     = "Labels need language tags, known candidates, contests and options"
+	errRunoff        = "Failed to start runoff"
 
-	listenMsg   = "HTTP Sever listening"
-	serviceName = "election"
+	listenMsg      = "HTTP Sever listening"
+	runoffMsg      = "Runoff election started"
+	serviceName    = "election"
+	commitmentName = "commitment"
+	resultsName    = "results"
+	codesName      = "codes"
+	weightsName    = "weights"
+	analysisName   = "analysis"
+	ballotName     = "ballot"
+	withdrawName   = "withdraw"
+	publicKeyPath  = "/.well-known/election-results-key"
+	acceptHeader   = "Accept-Language"
+	langHeader     = "Content-Language"
+	mongoName      = "mongo"
+	traceName      = "election-service"
 
-	elecIDKey  = "id"
-	stsCodeKey = "StatusCode"
+	elecIDKey       = "id"
+	voteElecIDKey   = "electionid"
+	resultElecIDKey = "results.electionid"
+	codeHashKey     = "hash"
+	voterKey        = "voter"
+	countKey        = "count"
+	topKKey         = "k"
+	candidateKey    = "candidate"
+	withdrawnKey    = "withdrawn"
+	contestKey      = "contest"
+	contestIDKey    = "contests.id"
+	optionKey       = "contests.$.withdrawn"
+	majorityKey     = "runoff.majority"
+	decidedKey      = "runoff.decided"
+	runoffIDKey     = "runoffid"
+	committedKey    = "committed"
+	endKey          = "end.seconds"
+	receiptKey      = "receipt"
+
+	maxCodes    = 100000
+	maxScore    = 100
+	defaultTopK = 2
+	maxRetries  = 10
+	pingTimeout = 2 * time.Second
+	stsCodeKey  = "StatusCode"
 )
 
 type server struct {
 	Port       string `envconfig:"PORT" default:"9223"`
 	Database   string `envconfig:"DATABASE" default:"elections"`
 	Collection string `envconfig:"COLLECTION" default:"election"`
+	VoteColl   string `envconfig:"VOTE_COLLECTION" default:"vote"`
+	CommitColl string `envconfig:"COMMITMENT_COLLECTION" default:"commitment"`
+	ProofColl  string `envconfig:"PROOF_COLLECTION" default:"proof"`
+	ResultColl string `envconfig:"RESULTS_COLLECTION" default:"results"`
+	CodeColl   string `envconfig:"CODE_COLLECTION" default:"code"`
+	WeightColl string `envconfig:"WEIGHT_COLLECTION" default:"weight"`
 	MgoURL     string `envconfig:"MONGO_URL" default:"localhost:27017"`
+	SigningKey string `envconfig:"SIGNING_KEY_FILE" required:"true"`
+	SecretKey  string `envconfig:"ELECTION_KEY_FILE" required:"true"`
+	Locale     string `envconfig:"DEFAULT_LOCALE" default:"en"`
+
+	// RunoffTick is how often closed elections are committed and checked for
+	// runoffs
+	RunoffTick time.Duration `envconfig:"RUNOFF_INTERVAL" default:"1m"`
+	// CommitDelay lets ballots validated before the end of an election, which
+	// the processor may still be storing, land before its ballots are
+	// committed. Ballots validated after the end are rejected.
+	CommitDelay time.Duration `envconfig:"COMMIT_DELAY" default:"1m"`
+	// ShutdownTimeout bounds how long shutdown waits for work in flight
+	ShutdownTimeout time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"30s"`
 
 	isOver            func(end *timestamp.Timestamp) bool
 	containsCandidate func(candidate string, candidates []string) bool
 
-	mgoDal db.DataAccessLayer
-	logger *zap.Logger
+	signingKey     ed25519.PrivateKey
+	electionSecret []byte
+	mgoDal         db.DataAccessLayer
+	logger         *zap.Logger
 }
 
 func (s *server) run() {
@@ -67,24 +195,134 @@ func (s *server) run() {
 		s.logger.Fatal(errEnvVarFail, zap.Error(err))
 	}
 
+	stopTracing, err := tracing.Init(context.Background(), traceName)
+	if err != nil {
+		s.logger.Fatal(errTracing, zap.Error(err))
+	}
+
+	s.signingKey, err = sign.LoadPrivateKey(s.SigningKey)
+	if err != nil {
+		s.logger.Fatal(errSigningKey, zap.Error(err))
+	}
+
+	s.electionSecret, err = ioutil.ReadFile(s.SecretKey)
+	if err == nil {
+		_, err = s.electionKey(0)
+	}
+	if err != nil {
+		s.logger.Fatal(errElectionKey, zap.Error(err))
+	}
+
 	srv := &http.Server{
 		Addr:    ":" + s.Port,
-		Handler: s.initRoutes(),
+		Handler: tracing.Handler(s.initRoutes()),
 	}
 
 	s.mgoDal, err = db.NewMongoDAL(s.MgoURL, s.Database)
 	if err != nil {
 		s.logger.Fatal(errConnFail, zap.Error(err))
 	}
+	s.mgoDal = metrics.DAL(s.mgoDal)
+
+	err = s.mgoDal.EnsureIndex(s.Collection, true, elecIDKey)
+	if err != nil {
+		s.logger.Fatal(errEnsureIndex, zap.Error(err))
+	}
+
+	err = s.mgoDal.EnsureIndex(s.CommitColl, true, voteElecIDKey)
+	if err != nil {
+		s.logger.Fatal(errEnsureIndex, zap.Error(err))
+	}
+
+	err = s.mgoDal.EnsureIndex(s.ProofColl, true, receiptKey)
+	if err != nil {
+		s.logger.Fatal(errEnsureIndex, zap.Error(err))
+	}
+
+	// ballots and proofs are read per election when committing and tallying
+	err = s.mgoDal.EnsureIndex(s.VoteColl, false, voteElecIDKey)
+	if err != nil {
+		s.logger.Fatal(errEnsureIndex, zap.Error(err))
+	}
+
+	err = s.mgoDal.EnsureIndex(s.ProofColl, false, voteElecIDKey)
+	if err != nil {
+		s.logger.Fatal(errEnsureIndex, zap.Error(err))
+	}
 
-	err = s.mgoDal.EnsureIndex(s.Collection, elecIDKey)
+	err = s.mgoDal.EnsureIndex(s.ResultColl, true, resultElecIDKey)
 	if err != nil {
 		s.logger.Fatal(errEnsureIndex, zap.Error(err))
 	}
 
+	err = s.mgoDal.EnsureIndex(s.CodeColl, true, voteElecIDKey, codeHashKey)
+	if err != nil {
+		s.logger.Fatal(errEnsureIndex, zap.Error(err))
+	}
+
+	err = s.mgoDal.EnsureIndex(s.WeightColl, true, voteElecIDKey, voterKey)
+	if err != nil {
+		s.logger.Fatal(errEnsureIndex, zap.Error(err))
+	}
+
+	var closing sync.WaitGroup
+	quit := make(chan struct{})
+	closing.Add(1)
+	go func() {
+		defer closing.Done()
+		s.closeElections(s.RunoffTick, quit)
+	}()
+
 	defer s.logger.Sync()
-	s.logger.Info(listenMsg, zap.String("Port", s.Port))
-	s.logger.Fatal(errInterrupt, zap.Error(srv.ListenAndServe()))
+	go func() {
+		s.logger.Info(listenMsg, zap.String("Port", s.Port))
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			s.logger.Fatal(errListen, zap.Error(err))
+		}
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	s.logger.Info(errInterrupt, zap.Stringer("Signal", <-signals))
+
+	close(quit)
+	err = s.shutdown(srv, &closing)
+	if err != nil {
+		s.logger.Error(errShutdown, zap.Error(err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+	defer cancel()
+	err = stopTracing(ctx)
+	if err != nil {
+		s.logger.Error(errShutdown, zap.Error(err))
+	}
+}
+
+// shutdown stops accepting requests and waits up to the shutdown timeout for
+// the requests in flight and a running commitment and runoff check before
+// closing the mongo session
+func (s *server) shutdown(srv *http.Server, closing *sync.WaitGroup) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+	defer cancel()
+	defer s.mgoDal.Close()
+
+	err := srv.Shutdown(ctx)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		closing.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func (s *server) initRoutes() *mux.Router {
@@ -92,10 +330,30 @@ func (s *server) initRoutes() *mux.Router {
 	router.HandleFunc("/"+serviceName, s.upsert).Methods(http.MethodPut)
 	router.HandleFunc("/"+serviceName+"/{"+elecIDKey+"}/validate", s.valid).Queries("candidate", "{candidate}").Methods(http.MethodGet)
 	router.HandleFunc("/"+serviceName+"/{"+elecIDKey+"}/validate", s.valid).Methods(http.MethodGet)
+	router.HandleFunc("/"+serviceName+"/{"+elecIDKey+"}/validate", s.valid).Methods(http.MethodPost)
+	router.HandleFunc("/"+serviceName+"/{"+elecIDKey+"}/"+commitmentName, s.commitment).Methods(http.MethodGet)
+	router.HandleFunc("/"+serviceName+"/{"+elecIDKey+"}/"+resultsName, s.results).Methods(http.MethodGet)
+	router.HandleFunc("/"+serviceName+"/{"+elecIDKey+"}/"+codesName, s.codes).Queries(countKey, "{"+countKey+"}").Methods(http.MethodPost)
+	router.HandleFunc("/"+serviceName+"/{"+elecIDKey+"}/"+weightsName, s.weights).Methods(http.MethodPut)
+	router.HandleFunc("/"+serviceName+"/{"+elecIDKey+"}/"+analysisName, s.analysis).Methods(http.MethodGet)
+	router.HandleFunc("/"+serviceName+"/{"+elecIDKey+"}/"+ballotName, s.ballot).Methods(http.MethodGet)
+	router.HandleFunc("/"+serviceName+"/{"+elecIDKey+"}/"+withdrawName, s.withdraw).Queries(candidateKey, "{"+candidateKey+"}").Methods(http.MethodPost)
+	router.HandleFunc(publicKeyPath, s.publicKey).Methods(http.MethodGet)
+	router.HandleFunc(health.LivePath, health.Live).Methods(http.MethodGet)
+	router.HandleFunc(health.ReadyPath, health.Ready(s.checks(), pingTimeout)).Methods(http.MethodGet)
+	router.Handle(metrics.Path, metrics.Handler()).Methods(http.MethodGet)
+	router.Use(metrics.Middleware)
 	router.HandleFunc("/"+serviceName+"/{"+elecIDKey+"}", s.delete).Methods(http.MethodDelete)
 	return router
 }
 
+// checks are the dependencies the service needs to be ready
+func (s *server) checks() map[string]health.Check {
+	return map[string]health.Check{
+		mongoName: func() error { return s.mgoDal.Ping() },
+	}
+}
+
 func (s *server) upsert(w http.ResponseWriter, r *http.Request) {
 	var (
 		err      error
@@ -113,12 +371,144 @@ func (s *server) upsert(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if election.GetId() == 0 || len(election.GetCandidates()) == 0 {
+	if msg := fillDetails(&election); msg != "" {
+		stsCode = http.StatusBadRequest
+		http.Error(w, msg, stsCode)
+		return
+	}
+
+	// an election either asks a single question or holds contests
+	if election.GetId() == 0 || (len(election.GetCandidates()) == 0) == (len(election.GetContests()) == 0) {
 		stsCode = http.StatusBadRequest
 		http.Error(w, errInvalidData, stsCode)
 		return
 	}
 
+	if msg := checkMethod(&election); msg != "" {
+		stsCode = http.StatusBadRequest
+		http.Error(w, msg, stsCode)
+		return
+	}
+
+	ids := make(map[string]bool, len(election.GetContests()))
+	for _, c := range election.GetContests() {
+		if c.GetId() == "" || ids[c.GetId()] || len(c.GetCandidates()) == 0 {
+			stsCode = http.StatusBadRequest
+			http.Error(w, errBadContest, stsCode)
+			return
+		}
+		ids[c.GetId()] = true
+
+		if msg := checkMethod(ballot.Contest(c)); msg != "" {
+			stsCode = http.StatusBadRequest
+			http.Error(w, msg, stsCode)
+			return
+		}
+	}
+
+	if msg := checkRules(&election); msg != "" {
+		stsCode = http.StatusBadRequest
+		http.Error(w, msg, stsCode)
+		return
+	}
+
+	if msg := checkTieBreak(&election); msg != "" {
+		stsCode = http.StatusBadRequest
+		http.Error(w, msg, stsCode)
+		return
+	}
+
+	if _, ok := pb.BallotOrder_name[int32(election.GetBallotOrder())]; !ok {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errInvalidOrder, stsCode)
+		return
+	}
+
+	if msg := checkWithdrawal(&election); msg != "" {
+		stsCode = http.StatusBadRequest
+		http.Error(w, msg, stsCode)
+		return
+	}
+
+	if msg := checkLabels(&election); msg != "" {
+		stsCode = http.StatusBadRequest
+		http.Error(w, msg, stsCode)
+		return
+	}
+
+	if msg := checkRunoff(&election); msg != "" {
+		stsCode = http.StatusBadRequest
+		http.Error(w, msg, stsCode)
+		return
+	}
+
+	if election.GetSecret() && len(election.GetContests()) > 0 {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errSecretContest, stsCode)
+		return
+	}
+
+	// encrypted ballots only hold a mark per candidate
+	if election.GetSecret() && (election.GetWriteIns() || election.GetAbstain()) {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errSecretWriteIn, stsCode)
+		return
+	}
+
+	if election.GetSecret() && election.GetWeighted() {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errSecretWeight, stsCode)
+		return
+	}
+
+	// encrypted ballots are summed per candidate, which only fits methods
+	// counting one mark per candidate
+	if election.GetSecret() && election.GetVotingMethod() != pb.VotingMethod_PLURALITY && election.GetVotingMethod() != pb.VotingMethod_APPROVAL {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errSecretMethod, stsCode)
+		return
+	}
+
+	var stored pb.Election
+	err = s.mgoDal.FindOne(s.Collection, bson.M{elecIDKey: election.GetId()}, &stored)
+	if err != nil && err != mgo.ErrNotFound {
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errRetrieveQuery, stsCode)
+		return
+	}
+	keep(&stored, &election)
+
+	// the seed is published with the election, before any ballot is counted
+	if election.GetTieBreak() == pb.TieBreak_RANDOM && election.GetSeed() == 0 {
+		election.Seed, err = newSeed()
+		if err != nil {
+			stsCode = http.StatusInternalServerError
+			http.Error(w, errSeed, stsCode)
+			return
+		}
+	}
+
+	if election.GetBallotOrder() == pb.BallotOrder_SHUFFLE && election.GetBallotSeed() == 0 {
+		election.BallotSeed, err = newSeed()
+		if err != nil {
+			stsCode = http.StatusInternalServerError
+			http.Error(w, errSeed, stsCode)
+			return
+		}
+	}
+
+	election.EncryptionKey = nil
+	if election.GetSecret() {
+		var key *elgamal.PrivateKey
+		key, err = s.electionKey(election.GetId())
+		if err != nil {
+			stsCode = http.StatusInternalServerError
+			http.Error(w, errElectionKey, stsCode)
+			return
+		}
+		election.EncryptionKey = key.Public()
+	}
+
 	election.Start = ptypes.TimestampNow()
 	err = s.mgoDal.Upsert(s.Collection, bson.M{elecIDKey: election.GetId()}, &election)
 	if err != nil {
@@ -132,6 +522,285 @@ func (s *server) upsert(w http.ResponseWriter, r *http.Request) {
 	w.Write(j)
 }
 
+// fillDetails lists the candidate ids from their details, or describes plain
+// candidates by their id, and returns the error message for details not
+// matching the candidates, or an empty string
+func fillDetails(e *pb.Election) string {
+	if len(e.GetDetails()) == 0 {
+		for _, c := range e.GetCandidates() {
+			e.Details = append(e.Details, &pb.Candidate{Id: c, Name: c})
+		}
+		return ""
+	}
+
+	ids := make([]string, 0, len(e.GetDetails()))
+	seen := make(map[string]bool, len(e.GetDetails()))
+	for _, d := range e.GetDetails() {
+		if d.GetId() == "" || d.GetName() == "" || seen[d.GetId()] {
+			return errBadDetails
+		}
+		seen[d.GetId()] = true
+		ids = append(ids, d.GetId())
+	}
+	if len(e.GetCandidates()) == 0 {
+		e.Candidates = ids
+		return ""
+	}
+	if len(e.GetCandidates()) != len(ids) {
+		return errBadDetails
+	}
+	for i, c := range e.GetCandidates() {
+		if c != ids[i] {
+			return errBadDetails
+		}
+	}
+	return ""
+}
+
+// checkMethod returns the error message for voting method settings that do
+// not fit the candidates, or an empty string
+func checkMethod(e *pb.Election) string {
+	if _, ok := pb.VotingMethod_name[int32(e.GetVotingMethod())]; !ok {
+		return errInvalidMethod
+	}
+	if e.GetSeats() < 0 || int(e.GetSeats()) > len(e.GetCandidates()) ||
+		(e.GetSeats() > 1 && e.GetVotingMethod() != pb.VotingMethod_STV) {
+		return errInvalidSeats
+	}
+	if e.GetMaxScore() < 0 || ((e.GetVotingMethod() == pb.VotingMethod_SCORE || e.GetVotingMethod() == pb.VotingMethod_STAR) && e.GetMaxScore() == 0) {
+		return errMaxScore
+	}
+	// score distributions hold a count per possible score
+	if e.GetMaxScore() > maxScore {
+		return errScoreLimit
+	}
+	return ""
+}
+
+// checkRules returns the error message for rules that can not be applied,
+// or an empty string. The majority compares the votes of the leader to the
+// ballots, which only holds for methods counting ballots. The turnout of
+// weighted elections is a weight, so it can not be compared to the number of
+// eligible voters.
+func checkRules(e *pb.Election) string {
+	r := e.GetRules()
+	if r == nil {
+		return ""
+	}
+	if r.GetQuorum() < 0 || r.GetEligible() < 0 || r.GetQuorumPercent() < 0 || r.GetQuorumPercent() > 100 ||
+		(r.GetQuorumPercent() > 0 && r.GetEligible() == 0) || r.GetMajority() < 0 || r.GetMajority() > 1 {
+		return errInvalidRules
+	}
+	if r.GetQuorumPercent() > 0 && e.GetWeighted() {
+		return errQuorumWeight
+	}
+	if r.GetMajority() == 0 {
+		return ""
+	}
+
+	methods := []pb.VotingMethod{e.GetVotingMethod()}
+	for _, c := range e.GetContests() {
+		methods = append(methods, c.GetVotingMethod())
+	}
+	for _, m := range methods {
+		if m != pb.VotingMethod_PLURALITY && m != pb.VotingMethod_APPROVAL && m != pb.VotingMethod_RANKED {
+			return errMajority
+		}
+	}
+	return ""
+}
+
+// checkTieBreak returns the error message for a tie-break policy that can not
+// be applied, or an empty string. Encrypted ballots can not tell which
+// candidate was chosen first, and a lot orders every candidate of the
+// election and its contests.
+func checkTieBreak(e *pb.Election) string {
+	if _, ok := pb.TieBreak_name[int32(e.GetTieBreak())]; !ok {
+		return errInvalidTie
+	}
+	if e.GetSecret() && e.GetTieBreak() == pb.TieBreak_EARLIEST {
+		return errSecretTie
+	}
+	if e.GetTieBreak() != pb.TieBreak_LOT {
+		return ""
+	}
+
+	candidates := make(map[string]bool)
+	for _, c := range e.GetCandidates() {
+		candidates[c] = true
+	}
+	for _, contest := range e.GetContests() {
+		for _, c := range contest.GetCandidates() {
+			candidates[c] = true
+		}
+	}
+	drawn := make(map[string]bool, len(e.GetLot()))
+	for _, c := range e.GetLot() {
+		if !candidates[c] || drawn[c] {
+			return errBadLot
+		}
+		drawn[c] = true
+	}
+	if len(drawn) != len(candidates) {
+		return errBadLot
+	}
+	return ""
+}
+
+// checkWithdrawal returns the error message for withdrawals that can not be
+// applied, or an empty string. Encrypted ballots can not be checked for
+// withdrawn candidates and only ranked ballots have a next preference, the
+// withdrawn options of a contest are checked against the contest.
+func checkWithdrawal(e *pb.Election) string {
+	if _, ok := pb.Withdrawal_name[int32(e.GetWithdrawal())]; !ok {
+		return errWithdrawal
+	}
+	if e.GetSecret() && (len(e.GetWithdrawn()) > 0 || e.GetWithdrawal() != pb.Withdrawal_KEEP) {
+		return errNoWithdraw
+	}
+	switch e.GetVotingMethod() {
+	case pb.VotingMethod_RANKED, pb.VotingMethod_SCHULZE, pb.VotingMethod_STV:
+	default:
+		if e.GetWithdrawal() == pb.Withdrawal_REDISTRIBUTE && len(e.GetContests()) == 0 {
+			return errWithdrawal
+		}
+	}
+	for _, c := range e.GetWithdrawn() {
+		if !containsCandidate(c, e.GetCandidates()) {
+			return errWithdrawal
+		}
+	}
+	for _, c := range e.GetContests() {
+		if len(c.GetWithdrawn()) == 0 {
+			continue
+		}
+		if msg := checkWithdrawal(&pb.Election{Candidates: c.GetCandidates(), VotingMethod: c.GetVotingMethod(), Withdrawn: c.GetWithdrawn(), Withdrawal: e.GetWithdrawal(), Secret: e.GetSecret()}); msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+// checkLabels puts the locale and the label languages of the election in
+// canonical BCP 47 form and returns the error message for tags that do not
+// parse or labels of unknown candidates and contests, or an empty string
+func checkLabels(e *pb.Election) string {
+	if e.GetLocale() != "" {
+		tag, err := language.Parse(e.GetLocale())
+		if err != nil {
+			return errBadLabels
+		}
+		e.Locale = tag.String()
+	}
+	if len(e.GetLabels()) == 0 {
+		return ""
+	}
+
+	contests := make(map[string][]string, len(e.GetContests()))
+	ids := make([]string, 0, len(e.GetContests()))
+	for _, c := range e.GetContests() {
+		contests[c.GetId()] = c.GetCandidates()
+		ids = append(ids, c.GetId())
+	}
+	labels := make(map[string]*pb.Labels, len(e.GetLabels()))
+	for l, t := range e.GetLabels() {
+		tag, err := language.Parse(l)
+		if err != nil || labels[tag.String()] != nil {
+			return errBadLabels
+		}
+		for c := range t.GetCandidates() {
+			if !containsCandidate(c, e.GetCandidates()) {
+				return errBadLabels
+			}
+		}
+		for c := range t.GetQuestions() {
+			if !containsCandidate(c, ids) {
+				return errBadLabels
+			}
+		}
+		for c, o := range t.GetOptions() {
+			if !containsCandidate(c, ids) {
+				return errBadLabels
+			}
+			for option := range o.GetNames() {
+				if !containsCandidate(option, contests[c]) {
+					return errBadLabels
+				}
+			}
+		}
+		if t == nil {
+			t = &pb.Labels{}
+		}
+		labels[tag.String()] = t
+	}
+	e.Labels = labels
+	return ""
+}
+
+// locale returns the language of the election that best matches the
+// Accept-Language header, the default locale of the election when none does
+func (s *server) locale(e *pb.Election, accept string) string {
+	def := e.GetLocale()
+	if def == "" {
+		def = s.Locale
+	}
+	locales := []string{def}
+	for l := range e.GetLabels() {
+		if l != def {
+			locales = append(locales, l)
+		}
+	}
+	sort.Strings(locales[1:])
+
+	desired, _, err := language.ParseAcceptLanguage(accept)
+	if err != nil || len(desired) == 0 {
+		return def
+	}
+	tags := make([]language.Tag, 0, len(locales))
+	for _, l := range locales {
+		tags = append(tags, language.Make(l))
+	}
+	_, i, confidence := language.NewMatcher(tags).Match(desired...)
+	if confidence == language.No {
+		return def
+	}
+	return locales[i]
+}
+
+// newSeed returns a random non-zero seed for random tie-breaks
+func newSeed() (int64, error) {
+	for {
+		var b [8]byte
+		_, err := rand.Read(b[:])
+		if err != nil {
+			return 0, err
+		}
+		seed := int64(binary.BigEndian.Uint64(b[:]) >> 1)
+		if seed != 0 {
+			return seed, nil
+		}
+	}
+}
+
+// checkRunoff returns the error message for a runoff policy that can not be
+// applied, or an empty string. Runoffs only follow single question plurality
+// elections. Voter weights and invitation codes are kept by election id, so
+// a runoff would have neither.
+func checkRunoff(e *pb.Election) string {
+	p := e.GetRunoff()
+	if p == nil {
+		return ""
+	}
+	if p.GetMajority() <= 0 || p.GetMajority() > 1 || p.GetDuration() <= 0 ||
+		len(e.GetContests()) > 0 || e.GetVotingMethod() != pb.VotingMethod_PLURALITY {
+		return errBadRunoff
+	}
+	if e.GetWeighted() || e.GetInviteOnly() {
+		return errRunoffVoters
+	}
+	return ""
+}
+
 func (s *server) valid(w http.ResponseWriter, r *http.Request) {
 	var (
 		err      error
@@ -170,12 +839,34 @@ func (s *server) valid(w http.ResponseWriter, r *http.Request) {
 	}
 
 	key := r.FormValue("candidate")
-	if key != "" && !s.containsCandidate(key, election.GetCandidates()) {
+	if key != "" && !election.GetWriteIns() && !s.containsCandidate(key, election.GetCandidates()) {
 		stsCode = http.StatusBadRequest
 		http.Error(w, "candidate not found", http.StatusBadRequest)
 		return
 	}
 
+	if key != "" && ballot.Withdrawn(&election, ballot.Resolve(&election, key)) {
+		stsCode = http.StatusBadRequest
+		http.Error(w, ballot.ErrWithdrawn.Error(), stsCode)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		var vote pb.Vote
+		err = json.NewDecoder(r.Body).Decode(&vote)
+		if err != nil {
+			stsCode = http.StatusBadRequest
+			http.Error(w, errInvalidBallot, stsCode)
+			return
+		}
+		err = ballot.Validate(&election, &vote)
+		if err != nil {
+			stsCode = http.StatusBadRequest
+			http.Error(w, err.Error(), stsCode)
+			return
+		}
+	}
+
 	w.WriteHeader(stsCode)
 	j, _ := json.Marshal(election)
 	w.Write(j)
@@ -210,6 +901,952 @@ func (s *server) delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(stsCode)
 }
 
+// commitment publishes the merkle root over the ballots of a closed election.
+// The root is built once by the closing loop after the commit delay, so it
+// never changes afterwards.
+func (s *server) commitment(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		election   pb.Election
+		commitment pb.Commitment
+		stsCode    = http.StatusOK
+		vars       = mux.Vars(r)
+		id         int64
+	)
+	defer func() {
+		defer s.logger.Info(http.MethodGet+commitmentName, zap.Error(err), zap.Int32(elecIDKey, election.GetId()), zap.Int(stsCodeKey, stsCode))
+	}()
+
+	id, err = strconv.ParseInt(vars[elecIDKey], 10, 32)
+	if err != nil {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errInvalidID, stsCode)
+		return
+	}
+
+	err = s.mgoDal.FindOne(s.Collection, bson.M{elecIDKey: id}, &election)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			stsCode = http.StatusNotFound
+			http.Error(w, errNotFound, stsCode)
+			return
+		}
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errRetrieveQuery, stsCode)
+		return
+	}
+
+	if !s.isOver(election.GetEnd()) {
+		stsCode = http.StatusConflict
+		http.Error(w, errNotOver, stsCode)
+		return
+	}
+
+	err = s.mgoDal.FindOne(s.CommitColl, bson.M{voteElecIDKey: id}, &commitment)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			stsCode = http.StatusConflict
+			http.Error(w, errNotCommitted, stsCode)
+			return
+		}
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errRetrieveQuery, stsCode)
+		return
+	}
+
+	w.WriteHeader(stsCode)
+	j, _ := json.Marshal(commitment)
+	w.Write(j)
+}
+
+// results publishes the signed final results of a closed election. They are
+// tallied from the committed ballots on the first request after the commitment
+// and stored, so the same signed document is served afterwards.
+func (s *server) results(w http.ResponseWriter, r *http.Request) {
+	var (
+		err      error
+		election pb.Election
+		signed   *pb.SignedResults
+		stsCode  = http.StatusOK
+		vars     = mux.Vars(r)
+		id       int64
+	)
+	defer func() {
+		defer s.logger.Info(http.MethodGet+resultsName, zap.Error(err), zap.Int32(elecIDKey, election.GetId()), zap.Int(stsCodeKey, stsCode))
+	}()
+
+	id, err = strconv.ParseInt(vars[elecIDKey], 10, 32)
+	if err != nil {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errInvalidID, stsCode)
+		return
+	}
+
+	err = s.mgoDal.FindOne(s.Collection, bson.M{elecIDKey: id}, &election)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			stsCode = http.StatusNotFound
+			http.Error(w, errNotFound, stsCode)
+			return
+		}
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errRetrieveQuery, stsCode)
+		return
+	}
+
+	if !s.isOver(election.GetEnd()) {
+		stsCode = http.StatusConflict
+		http.Error(w, errNotOver, stsCode)
+		return
+	}
+
+	if !election.GetCommitted() {
+		stsCode = http.StatusConflict
+		http.Error(w, errNotCommitted, stsCode)
+		return
+	}
+
+	signed = &pb.SignedResults{}
+	err = s.mgoDal.FindOne(s.ResultColl, bson.M{resultElecIDKey: id}, signed)
+	if err != nil && err != mgo.ErrNotFound {
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errRetrieveQuery, stsCode)
+		return
+	}
+
+	if err == mgo.ErrNotFound {
+		var results *pb.Results
+		results, err = s.tally(&election)
+		if err != nil {
+			stsCode = http.StatusInternalServerError
+			http.Error(w, errTally, stsCode)
+			return
+		}
+		results.ElectionId = election.GetId()
+		results.Created = ptypes.TimestampNow()
+
+		signed, err = sign.Results(s.signingKey, results)
+		if err != nil {
+			stsCode = http.StatusInternalServerError
+			http.Error(w, errResults, stsCode)
+			return
+		}
+
+		err = s.mgoDal.Insert(s.ResultColl, signed)
+		if err != nil {
+			stsCode = http.StatusInternalServerError
+			http.Error(w, errResults, stsCode)
+			return
+		}
+	}
+
+	w.WriteHeader(stsCode)
+	j, _ := json.Marshal(signed)
+	w.Write(j)
+}
+
+// tally counts the committed ballots of a closed election, decrypting the
+// sums of secret elections
+func (s *server) tally(election *pb.Election) (*pb.Results, error) {
+	votes, err := s.committedVotes(election)
+	if err != nil {
+		return nil, err
+	}
+	if !election.GetSecret() {
+		return tabulate.Count(election, votes), nil
+	}
+
+	results := &pb.Results{}
+	results.Tallies, results.Ballots, err = s.decryptTally(election, votes)
+	if err != nil {
+		return nil, err
+	}
+	results.Tie = tabulate.Tie(election, tabulate.TieBreak(election, nil, election.GetCandidates()), results)
+	if election.GetRules() != nil {
+		results.Verdict = tabulate.Judge(election.GetVotingMethod(), election.GetRules(), results, results.GetBallots(), 0)
+	}
+	return results, nil
+}
+
+// closeElections commits the ballots and starts the runoffs of closed
+// elections every interval until quit is closed
+func (s *server) closeElections(interval time.Duration, quit <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-quit:
+			return
+		case <-ticker.C:
+			s.commitClosed()
+			err := s.startRunoffs()
+			if err != nil {
+				s.logger.Error(errRunoff, zap.Error(err))
+			}
+		}
+	}
+}
+
+// commitClosed commits the ballots of every election that ended more than
+// the commit delay ago and is not committed yet. A failed election is logged
+// and retried on the next tick.
+func (s *server) commitClosed() {
+	var elections []pb.Election
+	cutoff := time.Now().Add(-s.CommitDelay).Unix()
+	err := s.mgoDal.FindAll(s.Collection, bson.M{committedKey: bson.M{"$ne": true}, endKey: bson.M{"$lt": cutoff}}, &elections)
+	if err != nil {
+		s.logger.Error(errCommit, zap.Error(err))
+		return
+	}
+
+	for i := range elections {
+		err = s.commit(&elections[i])
+		if err != nil {
+			s.logger.Error(errCommit, zap.Error(err), zap.Int32(elecIDKey, elections[i].GetId()))
+		}
+	}
+}
+
+// commit stores the merkle root over the ballots of a closed election and
+// the inclusion proof of every receipt, then marks the election committed.
+// Each step can be repeated, so a failed commit is simply retried.
+func (s *server) commit(e *pb.Election) error {
+	var votes []pb.Vote
+	err := s.mgoDal.FindAll(s.VoteColl, bson.M{voteElecIDKey: e.GetId()}, &votes)
+	if err != nil {
+		return err
+	}
+
+	tree, err := merkle.Ballots(votes)
+	if err != nil {
+		return err
+	}
+	root := hex.EncodeToString(tree.Root())
+
+	for i := range votes {
+		path, err := tree.Proof(i)
+		if err != nil {
+			return err
+		}
+		leaf, err := merkle.BallotLeaf(&votes[i])
+		if err != nil {
+			return err
+		}
+
+		p := pb.InclusionProof{
+			Receipt:    votes[i].GetReceipt(),
+			ElectionId: e.GetId(),
+			Ballot:     hex.EncodeToString(leaf),
+			Index:      int32(i),
+			Root:       root,
+		}
+		for _, step := range path {
+			p.Path = append(p.Path, &pb.InclusionProof_Step{Hash: hex.EncodeToString(step.Hash), Left: step.Left})
+		}
+		err = s.mgoDal.Upsert(s.ProofColl, bson.M{receiptKey: p.GetReceipt()}, &p)
+		if err != nil {
+			return err
+		}
+	}
+
+	commitment := pb.Commitment{
+		ElectionId: e.GetId(),
+		Root:       root,
+		Ballots:    int32(len(votes)),
+		Created:    ptypes.TimestampNow(),
+	}
+	err = s.mgoDal.Upsert(s.CommitColl, bson.M{voteElecIDKey: e.GetId()}, &commitment)
+	if err != nil {
+		return err
+	}
+
+	return s.mgoDal.Update(s.Collection, bson.M{elecIDKey: e.GetId()}, bson.M{"$set": bson.M{committedKey: true}})
+}
+
+// startRunoffs decides the runoff of every committed election with a runoff
+// policy that is not decided yet
+func (s *server) startRunoffs() error {
+	var elections []pb.Election
+	err := s.mgoDal.FindAll(s.Collection, bson.M{majorityKey: bson.M{"$gt": 0}, decidedKey: false, committedKey: true}, &elections)
+	if err != nil {
+		return err
+	}
+
+	for i := range elections {
+		if !s.isOver(elections[i].GetEnd()) {
+			continue
+		}
+		err = s.startRunoff(&elections[i])
+		if err != nil {
+			s.logger.Error(errRunoff, zap.Error(err), zap.Int32(elecIDKey, elections[i].GetId()))
+		}
+	}
+	return nil
+}
+
+// startRunoff creates a plurality election between the two leading
+// candidates when none of them reached the majority, an election without
+// votes for any candidate has no runoff. The tallies are ordered by the
+// tie-break policy of the parent, a declared tie for second place sends every
+// tied candidate to the runoff. The runoff breaks ties and orders its ballots
+// like its parent. The parent election is
+// claimed first, so only one runoff is ever started for it, and released
+// again when the runoff can not be inserted.
+func (s *server) startRunoff(parent *pb.Election) error {
+	results, err := s.tally(parent)
+	if err != nil {
+		return err
+	}
+
+	tallies := results.GetTallies()
+	var votes int64
+	for _, t := range tallies {
+		votes += t.GetVotes()
+	}
+	verdict := tabulate.Judge(parent.GetVotingMethod(), &pb.Rules{Majority: parent.GetRunoff().GetMajority()}, results, votes, 0)
+	if votes == 0 || verdict.GetPassed() || len(tallies) < 2 {
+		return s.claimRunoff(parent.GetId(), 0, 0)
+	}
+
+	end, err := ptypes.TimestampProto(time.Now().Add(time.Duration(parent.GetRunoff().GetDuration()) * time.Second))
+	if err != nil {
+		return err
+	}
+	candidates := []string{tallies[0].GetCandidate(), tallies[1].GetCandidate()}
+	for _, t := range tallies[2:] {
+		if parent.GetTieBreak() != pb.TieBreak_DECLARE || t.GetVotes() != tallies[1].GetVotes() {
+			break
+		}
+		candidates = append(candidates, t.GetCandidate())
+	}
+	runoff := pb.Election{
+		Candidates:  candidates,
+		Start:       ptypes.TimestampNow(),
+		End:         end,
+		Secret:      parent.GetSecret(),
+		Abstain:     parent.GetAbstain(),
+		Rules:       parent.GetRules(),
+		Parent:      parent.GetId(),
+		Title:       parent.GetTitle(),
+		Description: parent.GetDescription(),
+		Locale:      parent.GetLocale(),
+		Labels:      parent.GetLabels(),
+		TieBreak:    parent.GetTieBreak(),
+		Seed:        parent.GetSeed(),
+		BallotOrder: parent.GetBallotOrder(),
+		BallotSeed:  parent.GetBallotSeed(),
+	}
+	for _, c := range parent.GetLot() {
+		if containsCandidate(c, candidates) {
+			runoff.Lot = append(runoff.Lot, c)
+		}
+	}
+	for _, c := range runoff.GetCandidates() {
+		if d := ballot.Details(parent, c); d != nil {
+			runoff.Details = append(runoff.Details, d)
+		}
+	}
+
+	id, err := s.nextID()
+	if err != nil {
+		return err
+	}
+	err = s.claimRunoff(parent.GetId(), 0, id)
+	if err == mgo.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	id, err = s.insertRunoff(parent.GetId(), &runoff, id)
+	if err != nil {
+		if rerr := s.releaseRunoff(parent.GetId(), id); rerr != nil {
+			s.logger.Error(errRunoff, zap.Error(rerr), zap.Int32(elecIDKey, parent.GetId()))
+		}
+		return err
+	}
+
+	s.logger.Info(runoffMsg, zap.Int32(elecIDKey, id), zap.Int32("parent", parent.GetId()))
+	return nil
+}
+
+// insertRunoff inserts the runoff under the claimed id. Another election may
+// take the id between picking and inserting it, then the claim moves to the
+// next id. It returns the id last claimed.
+func (s *server) insertRunoff(parent int32, runoff *pb.Election, id int32) (int32, error) {
+	for i := 0; ; i++ {
+		runoff.Id = id
+		runoff.EncryptionKey = nil
+		if runoff.GetSecret() {
+			key, err := s.electionKey(id)
+			if err != nil {
+				return id, err
+			}
+			runoff.EncryptionKey = key.Public()
+		}
+
+		err := s.mgoDal.Insert(s.Collection, runoff)
+		if err == nil || !mgo.IsDup(err) || i == maxRetries {
+			return id, err
+		}
+
+		next, err := s.nextID()
+		if err != nil {
+			return id, err
+		}
+		err = s.claimRunoff(parent, id, next)
+		if err != nil {
+			return id, err
+		}
+		id = next
+	}
+}
+
+// claimRunoff marks the runoff of the election as decided, moving it from
+// the runoff previously recorded to id. It returns mgo.ErrNotFound when the
+// runoff was recorded by someone else.
+func (s *server) claimRunoff(election, previous, id int32) error {
+	selector := bson.M{elecIDKey: election, runoffIDKey: previous, decidedKey: previous != 0}
+	return s.mgoDal.Update(s.Collection, selector, bson.M{"$set": bson.M{decidedKey: true, runoffIDKey: id}})
+}
+
+// releaseRunoff undoes the claim of id, so the runoff is started again on
+// the next check
+func (s *server) releaseRunoff(election, id int32) error {
+	selector := bson.M{elecIDKey: election, runoffIDKey: id, decidedKey: true}
+	return s.mgoDal.Update(s.Collection, selector, bson.M{"$set": bson.M{decidedKey: false, runoffIDKey: 0}})
+}
+
+// nextID returns the id following the highest election id
+func (s *server) nextID() (int32, error) {
+	var last pb.Election
+	err := s.mgoDal.FindFirst(s.Collection, bson.M{}, "-"+elecIDKey, &last)
+	if err != nil && err != mgo.ErrNotFound {
+		return 0, err
+	}
+	return last.GetId() + 1, nil
+}
+
+// committedVotes returns the ballots the commitment of the election covers,
+// those with an inclusion proof, so ballots stored after the commitment are
+// never counted. They must still hash to the committed root.
+func (s *server) committedVotes(election *pb.Election) ([]pb.Vote, error) {
+	var (
+		commitment pb.Commitment
+		proofs     []pb.InclusionProof
+		stored     []pb.Vote
+	)
+	err := s.mgoDal.FindOne(s.CommitColl, bson.M{voteElecIDKey: election.GetId()}, &commitment)
+	if err != nil {
+		return nil, err
+	}
+	err = s.mgoDal.FindAll(s.ProofColl, bson.M{voteElecIDKey: election.GetId()}, &proofs)
+	if err != nil {
+		return nil, err
+	}
+	err = s.mgoDal.FindAll(s.VoteColl, bson.M{voteElecIDKey: election.GetId()}, &stored)
+	if err != nil {
+		return nil, err
+	}
+
+	proven := make(map[string]bool, len(proofs))
+	for _, p := range proofs {
+		proven[p.GetReceipt()] = true
+	}
+	votes := make([]pb.Vote, 0, len(proofs))
+	for _, v := range stored {
+		if proven[v.GetReceipt()] {
+			votes = append(votes, v)
+		}
+	}
+
+	tree, err := merkle.Ballots(votes)
+	if err != nil {
+		return nil, err
+	}
+	if len(votes) != int(commitment.GetBallots()) || hex.EncodeToString(tree.Root()) != commitment.GetRoot() {
+		return nil, errors.New(errRootMismatch)
+	}
+	return votes, nil
+}
+
+// decryptTally adds up the encrypted ballots of the election and decrypts
+// the sums, the only plaintext ever derived from the ballots of a secret
+// election. Every stored ballot carries a proof that it adds at most one
+// vote per candidate, so the sums stay within the number of ballots.
+func (s *server) decryptTally(election *pb.Election, votes []pb.Vote) ([]*pb.Tally, int64, error) {
+	var (
+		counts = make(map[string]int64, len(election.GetCandidates()))
+		order  = tabulate.TieBreak(election, nil, election.GetCandidates())
+		err    error
+	)
+	for _, c := range election.GetCandidates() {
+		counts[c] = 0
+	}
+
+	if len(votes) == 0 {
+		return tabulate.Tallies(counts, order), 0, nil
+	}
+
+	sums := votes[0].GetEncrypted()
+	for i := 1; i < len(votes); i++ {
+		sums, err = elgamal.AddVectors(sums, votes[i].GetEncrypted())
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	if len(sums) != len(election.GetCandidates()) {
+		return nil, 0, elgamal.ErrLengthMismatch
+	}
+
+	key, err := s.electionKey(election.GetId())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ballots := int64(len(votes))
+	for i, c := range election.GetCandidates() {
+		counts[c], err = elgamal.Decrypt(key, sums[i], ballots)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	return tabulate.Tallies(counts, order), ballots, nil
+}
+
+// electionKey returns the private key of a secret election. It is derived
+// from the election key file, so it is never stored and stays the same
+// across updates.
+func (s *server) electionKey(id int32) (*elgamal.PrivateKey, error) {
+	return elgamal.DeriveKey(s.electionSecret, id)
+}
+
+// codes generates one-time codes for an invite only election and exports them
+// as CSV. Only their hashes are stored, so this is the only time they are seen.
+func (s *server) codes(w http.ResponseWriter, r *http.Request) {
+	var (
+		err      error
+		election pb.Election
+		codes    []string
+		stsCode  = http.StatusCreated
+		vars     = mux.Vars(r)
+		id       int64
+		count    int
+	)
+	defer func() {
+		defer s.logger.Info(http.MethodPost+codesName, zap.Error(err), zap.Int32(elecIDKey, election.GetId()), zap.Int(countKey, count), zap.Int(stsCodeKey, stsCode))
+	}()
+
+	id, err = strconv.ParseInt(vars[elecIDKey], 10, 32)
+	if err != nil {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errInvalidID, stsCode)
+		return
+	}
+
+	count, err = strconv.Atoi(r.FormValue(countKey))
+	if err != nil || count <= 0 || count > maxCodes {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errInvalidCount, stsCode)
+		return
+	}
+
+	err = s.mgoDal.FindOne(s.Collection, bson.M{elecIDKey: id}, &election)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			stsCode = http.StatusNotFound
+			http.Error(w, errNotFound, stsCode)
+			return
+		}
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errRetrieveQuery, stsCode)
+		return
+	}
+
+	if !election.GetInviteOnly() {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errNotInvite, stsCode)
+		return
+	}
+
+	codes, err = invite.Generate(count)
+	if err != nil {
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errCodes, stsCode)
+		return
+	}
+
+	docs := make([]interface{}, len(codes))
+	for i, c := range codes {
+		docs[i] = &pb.VotingCode{ElectionId: election.GetId(), Hash: invite.Hash(c)}
+	}
+	err = s.mgoDal.InsertAll(s.CodeColl, docs)
+	if err != nil {
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errCodes, stsCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=election-"+strconv.Itoa(int(id))+"-codes.csv")
+	w.WriteHeader(stsCode)
+	out := csv.NewWriter(w)
+	out.Write([]string{serviceName, "code"})
+	for _, c := range codes {
+		out.Write([]string{strconv.Itoa(int(id)), c})
+	}
+	out.Flush()
+}
+
+// analysis compares how the ranked ballots of a closed election come out
+// under several counting methods
+func (s *server) analysis(w http.ResponseWriter, r *http.Request) {
+	var (
+		err      error
+		election pb.Election
+		votes    []pb.Vote
+		stsCode  = http.StatusOK
+		vars     = mux.Vars(r)
+		id       int64
+		k        = defaultTopK
+	)
+	defer func() {
+		defer s.logger.Info(http.MethodGet+analysisName, zap.Error(err), zap.Int32(elecIDKey, election.GetId()), zap.Int(stsCodeKey, stsCode))
+	}()
+
+	id, err = strconv.ParseInt(vars[elecIDKey], 10, 32)
+	if err != nil {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errInvalidID, stsCode)
+		return
+	}
+
+	err = s.mgoDal.FindOne(s.Collection, bson.M{elecIDKey: id}, &election)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			stsCode = http.StatusNotFound
+			http.Error(w, errNotFound, stsCode)
+			return
+		}
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errRetrieveQuery, stsCode)
+		return
+	}
+
+	switch election.GetVotingMethod() {
+	case pb.VotingMethod_RANKED, pb.VotingMethod_SCHULZE, pb.VotingMethod_STV:
+	default:
+		stsCode = http.StatusBadRequest
+		http.Error(w, errNotRanked, stsCode)
+		return
+	}
+
+	if k > len(election.GetCandidates()) {
+		k = len(election.GetCandidates())
+	}
+	if r.FormValue(topKKey) != "" {
+		k, err = strconv.Atoi(r.FormValue(topKKey))
+		if err != nil || k < 1 || k > len(election.GetCandidates()) {
+			stsCode = http.StatusBadRequest
+			http.Error(w, errInvalidTopK, stsCode)
+			return
+		}
+	}
+
+	if !s.isOver(election.GetEnd()) {
+		stsCode = http.StatusConflict
+		http.Error(w, errNotOver, stsCode)
+		return
+	}
+
+	err = s.mgoDal.FindAll(s.VoteColl, bson.M{voteElecIDKey: election.GetId()}, &votes)
+	if err != nil {
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errRetrieveQuery, stsCode)
+		return
+	}
+
+	analysis := tabulate.Compare(&election, votes, k)
+	analysis.ElectionId = election.GetId()
+
+	w.WriteHeader(stsCode)
+	j, _ := json.Marshal(analysis)
+	w.Write(j)
+}
+
+// ballot returns the candidates in the order the voter given by the voter
+// query parameter is shown them
+func (s *server) ballot(w http.ResponseWriter, r *http.Request) {
+	var (
+		err      error
+		election pb.Election
+		stsCode  = http.StatusOK
+		vars     = mux.Vars(r)
+		id       int64
+		voter    = r.FormValue(voterKey)
+	)
+	defer func() {
+		defer s.logger.Info(http.MethodGet+ballotName, zap.Error(err), zap.Int32(elecIDKey, election.GetId()), zap.Int(stsCodeKey, stsCode))
+	}()
+
+	id, err = strconv.ParseInt(vars[elecIDKey], 10, 32)
+	if err != nil {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errInvalidID, stsCode)
+		return
+	}
+
+	err = s.mgoDal.FindOne(s.Collection, bson.M{elecIDKey: id}, &election)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			stsCode = http.StatusNotFound
+			http.Error(w, errNotFound, stsCode)
+			return
+		}
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errRetrieveQuery, stsCode)
+		return
+	}
+
+	if voter == "" && ballot.PerVoter(&election) {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errNoVoter, stsCode)
+		return
+	}
+
+	b := ballot.Order(&election, voter)
+	ballot.Localize(b, &election, s.locale(&election, r.Header.Get(acceptHeader)))
+	w.Header().Set(langHeader, b.GetLocale())
+	w.Header().Add("Vary", acceptHeader)
+	w.WriteHeader(stsCode)
+	j, _ := json.Marshal(b)
+	w.Write(j)
+}
+
+// withdraw marks a candidate of an open election, or an option of one of its
+// contests, withdrawn. New ballots can no longer choose it and the withdrawal
+// policy of the election decides over the ballots already cast
+func (s *server) withdraw(w http.ResponseWriter, r *http.Request) {
+	var (
+		err       error
+		election  pb.Election
+		stsCode   = http.StatusOK
+		vars      = mux.Vars(r)
+		id        int64
+		candidate = r.FormValue(candidateKey)
+		contestID = r.FormValue(contestKey)
+	)
+	defer func() {
+		defer s.logger.Info(http.MethodPost+withdrawName, zap.Error(err), zap.Int32(elecIDKey, election.GetId()), zap.String(contestKey, contestID), zap.String(candidateKey, candidate), zap.Int(stsCodeKey, stsCode))
+	}()
+
+	id, err = strconv.ParseInt(vars[elecIDKey], 10, 32)
+	if err != nil {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errInvalidID, stsCode)
+		return
+	}
+
+	err = s.mgoDal.FindOne(s.Collection, bson.M{elecIDKey: id}, &election)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			stsCode = http.StatusNotFound
+			http.Error(w, errNotFound, stsCode)
+			return
+		}
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errRetrieveQuery, stsCode)
+		return
+	}
+
+	if election.GetSecret() {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errNoWithdraw, stsCode)
+		return
+	}
+
+	// the withdrawn list of a contest is updated through the positional
+	// operator on the matched contest
+	candidates, withdrawn := election.GetCandidates(), &election.Withdrawn
+	selector, update := bson.M{elecIDKey: id}, bson.M{"$addToSet": bson.M{withdrawnKey: candidate}}
+	if contestID != "" {
+		candidates, withdrawn = nil, nil
+		for _, c := range election.GetContests() {
+			if c.GetId() == contestID {
+				candidates, withdrawn = c.GetCandidates(), &c.Withdrawn
+			}
+		}
+		selector, update = bson.M{elecIDKey: id, contestIDKey: contestID}, bson.M{"$addToSet": bson.M{optionKey: candidate}}
+	}
+
+	if !s.containsCandidate(candidate, candidates) {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errCandidate, stsCode)
+		return
+	}
+
+	if s.isOver(election.GetEnd()) {
+		stsCode = http.StatusConflict
+		http.Error(w, errOver, stsCode)
+		return
+	}
+
+	if !containsCandidate(candidate, *withdrawn) {
+		*withdrawn = append(*withdrawn, candidate)
+	}
+	if msg := checkWithdrawal(&election); msg != "" {
+		stsCode = http.StatusBadRequest
+		http.Error(w, msg, stsCode)
+		return
+	}
+
+	err = s.mgoDal.Update(s.Collection, selector, update)
+	if err != nil {
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errUpsert, stsCode)
+		return
+	}
+
+	w.WriteHeader(stsCode)
+	j, _ := json.Marshal(election)
+	w.Write(j)
+}
+
+// weights imports the voter weights of a weighted election from a CSV body
+// with voter and weight columns, voters imported again get the new weight
+func (s *server) weights(w http.ResponseWriter, r *http.Request) {
+	var (
+		err      error
+		election pb.Election
+		records  [][]string
+		weights  []*pb.VoterWeight
+		stsCode  = http.StatusCreated
+		vars     = mux.Vars(r)
+		id       int64
+	)
+	defer func() {
+		defer s.logger.Info(http.MethodPut+weightsName, zap.Error(err), zap.Int32(elecIDKey, election.GetId()), zap.Int(countKey, len(weights)), zap.Int(stsCodeKey, stsCode))
+	}()
+
+	id, err = strconv.ParseInt(vars[elecIDKey], 10, 32)
+	if err != nil {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errInvalidID, stsCode)
+		return
+	}
+
+	err = s.mgoDal.FindOne(s.Collection, bson.M{elecIDKey: id}, &election)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			stsCode = http.StatusNotFound
+			http.Error(w, errNotFound, stsCode)
+			return
+		}
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errRetrieveQuery, stsCode)
+		return
+	}
+
+	if !election.GetWeighted() {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errNotWeighted, stsCode)
+		return
+	}
+
+	if s.isOver(election.GetEnd()) {
+		stsCode = http.StatusConflict
+		http.Error(w, errOver, stsCode)
+		return
+	}
+
+	in := csv.NewReader(r.Body)
+	in.FieldsPerRecord = 2
+	records, err = in.ReadAll()
+	if err == nil && len(records) > 0 && records[0][0] == voterKey {
+		records = records[1:]
+	}
+	for _, rec := range records {
+		var weight int64
+		weight, err = strconv.ParseInt(rec[1], 10, 64)
+		if err != nil || weight <= 0 || rec[0] == "" {
+			break
+		}
+		weights = append(weights, &pb.VoterWeight{ElectionId: election.GetId(), Voter: rec[0], Weight: weight})
+	}
+	if err != nil || len(weights) != len(records) || len(weights) == 0 {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errInvalidWeight, stsCode)
+		return
+	}
+
+	for _, vw := range weights {
+		err = s.mgoDal.Upsert(s.WeightColl, bson.M{voteElecIDKey: vw.GetElectionId(), voterKey: vw.GetVoter()}, vw)
+		if err != nil {
+			stsCode = http.StatusInternalServerError
+			http.Error(w, errWeights, stsCode)
+			return
+		}
+	}
+
+	w.WriteHeader(stsCode)
+	j, _ := json.Marshal(map[string]int{countKey: len(weights)})
+	w.Write(j)
+}
+
+// publicKey serves the PEM encoded key that verifies signed results
+func (s *server) publicKey(w http.ResponseWriter, r *http.Request) {
+	var (
+		err     error
+		key     []byte
+		stsCode = http.StatusOK
+	)
+	defer func() {
+		defer s.logger.Info(http.MethodGet+publicKeyPath, zap.Error(err), zap.Int(stsCodeKey, stsCode))
+	}()
+
+	key, err = sign.MarshalPublicKey(s.signingKey.Public().(ed25519.PublicKey))
+	if err != nil {
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errPublicKey, stsCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	w.WriteHeader(stsCode)
+	w.Write(key)
+}
+
+// keep copies the fields only the service sets from the stored election, so
+// a client can not change them by replacing the election
+func keep(stored, e *pb.Election) {
+	e.Committed = stored.GetCommitted()
+	e.Parent = stored.GetParent()
+	e.RunoffId = stored.GetRunoffId()
+	e.Seed = stored.GetSeed()
+	e.BallotSeed = stored.GetBallotSeed()
+	// withdrawals are made through the withdraw route, a replacement leaving
+	// them out keeps them
+	if e.Withdrawn == nil {
+		e.Withdrawn = stored.GetWithdrawn()
+	}
+	for _, c := range e.GetContests() {
+		for _, old := range stored.GetContests() {
+			if c.GetId() == old.GetId() && c.Withdrawn == nil {
+				c.Withdrawn = old.GetWithdrawn()
+			}
+		}
+	}
+	if stored.GetRunoff().GetDecided() {
+		if e.Runoff == nil {
+			e.Runoff = &pb.RunoffPolicy{}
+		}
+		e.Runoff.Decided = true
+	} else if e.Runoff != nil {
+		e.Runoff.Decided = false
+	}
+}
+
 // todo: refactor isOver containsCandidate
 func isOver(end *timestamp.Timestamp) bool {
 	t, err := ptypes.Timestamp(end)
@@ -1,13 +1,28 @@
 package main
 
 import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/ednesic/vote-test/ballot"
+	"github.com/ednesic/vote-test/elgamal"
+	"github.com/ednesic/vote-test/health"
+	"github.com/ednesic/vote-test/invite"
+	"github.com/ednesic/vote-test/merkle"
+	"github.com/ednesic/vote-test/pb"
+	"github.com/ednesic/vote-test/sign"
 	"github.com/ednesic/vote-test/tests"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/timestamp"
@@ -16,6 +31,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
 	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
 )
 
 func Test_server_upsert(t *testing.T) {
@@ -29,17 +45,69 @@ func Test_server_upsert(t *testing.T) {
 		queryRet   error
 	}{
 		{"Create Election", `{"id": 3, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
+		{"Create secret Election", `{"id": 3, "secret": true, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
 		{"Upsert function do not work", `{"id": 3, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusInternalServerError, errors.New("Upsert fail")},
 		{"Id = 0", `{"id": 0, "candidates": ["test1", "test2"], "end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
 		{"Without candidates", `{"id": 4, "candidates": [], "end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
 		{"Empty candidates", `{"id": 4, "end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
 		{"Invalid payload", ``, http.StatusBadRequest, nil},
+		{"Create ranked Election", `{"id": 3, "voting_method": 2, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
+		{"Create secret ranked Election", `{"id": 3, "secret": true, "voting_method": 2, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Create STV Election", `{"id": 3, "voting_method": 5, "seats": 2, "candidates": ["test1", "test2", "test3"],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
+		{"More seats than candidates", `{"id": 3, "voting_method": 5, "seats": 4, "candidates": ["test1", "test2", "test3"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Seats on plurality Election", `{"id": 3, "seats": 2, "candidates": ["test1", "test2", "test3"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Create STAR Election", `{"id": 3, "voting_method": 6, "max_score": 5, "candidates": ["test1", "test2", "test3"],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
+		{"Score Election without max score", `{"id": 3, "voting_method": 3, "candidates": ["test1", "test2", "test3"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Score Election with max score 100", `{"id": 3, "voting_method": 3, "max_score": 100, "candidates": ["test1", "test2", "test3"],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
+		{"Max score above 100", `{"id": 3, "voting_method": 3, "max_score": 2000000000, "candidates": ["test1", "test2", "test3"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Contest max score above 100", `{"id": 3, "contests": [{"id": "q1", "voting_method": 3, "max_score": 101, "candidates": ["yes", "no"]}],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Create weighted Election", `{"id": 3, "weighted": true, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
+		{"Secret weighted Election", `{"id": 3, "secret": true, "weighted": true, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Create referendum", `{"id": 3, "contests": [{"id": "q1", "candidates": ["yes", "no"]}, {"id": "q2", "voting_method": 2, "candidates": ["test1", "test2"]}],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
+		{"Referendum with candidates", `{"id": 3, "candidates": ["test1"], "contests": [{"id": "q1", "candidates": ["yes", "no"]}],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Duplicate contest id", `{"id": 3, "contests": [{"id": "q1", "candidates": ["yes", "no"]}, {"id": "q1", "candidates": ["yes", "no"]}],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Contest without candidates", `{"id": 3, "contests": [{"id": "q1"}],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Contest with invalid seats", `{"id": 3, "contests": [{"id": "q1", "seats": 2, "candidates": ["yes", "no"]}],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Secret referendum", `{"id": 3, "secret": true, "contests": [{"id": "q1", "candidates": ["yes", "no"]}],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Create Election with write-ins and abstentions", `{"id": 3, "write_ins": true, "abstain": true, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
+		{"Secret Election with write-ins", `{"id": 3, "secret": true, "write_ins": true, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Create Election with rules", `{"id": 3, "rules": {"quorum": 10, "majority": 0.6666666666666666}, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
+		{"Quorum percent without eligible voters", `{"id": 3, "rules": {"quorum_percent": 50}, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Quorum percent on weighted Election", `{"id": 3, "weighted": true, "rules": {"quorum_percent": 50, "eligible": 100}, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Majority above one", `{"id": 3, "rules": {"majority": 1.5}, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Majority on score Election", `{"id": 3, "voting_method": 3, "max_score": 5, "rules": {"majority": 0.5}, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Create Election with runoff", `{"id": 3, "runoff": {"majority": 0.5, "duration": 86400}, "candidates": ["test1", "test2", "test3"],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
+		{"Runoff without duration", `{"id": 3, "runoff": {"majority": 0.5}, "candidates": ["test1", "test2", "test3"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Runoff on ranked Election", `{"id": 3, "voting_method": 2, "runoff": {"majority": 0.5, "duration": 86400}, "candidates": ["test1", "test2", "test3"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Runoff on weighted Election", `{"id": 3, "weighted": true, "runoff": {"majority": 0.5, "duration": 86400}, "candidates": ["test1", "test2", "test3"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Runoff on invite only Election", `{"id": 3, "invite_only": true, "runoff": {"majority": 0.5, "duration": 86400}, "candidates": ["test1", "test2", "test3"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Create Election with random tie-break", `{"id": 3, "tie_break": 1, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
+		{"Create Election with lot", `{"id": 3, "tie_break": 3, "lot": ["test2", "test1"], "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
+		{"Lot missing a candidate", `{"id": 3, "tie_break": 3, "lot": ["test2"], "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Secret Election with earliest vote tie-break", `{"id": 3, "secret": true, "tie_break": 2, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Unknown tie-break policy", `{"id": 3, "tie_break": 7, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Create Election with shuffled ballots", `{"id": 3, "ballot_order": 2, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
+		{"Unknown ballot order", `{"id": 3, "ballot_order": 9, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Create Election with withdrawn candidate", `{"id": 3, "voting_method": 2, "withdrawn": ["test2"], "withdrawal": 2, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
+		{"Withdrawn candidate not a candidate", `{"id": 3, "withdrawn": ["test3"], "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Create referendum with withdrawn option", `{"id": 3, "withdrawal": 2, "contests": [{"id": "q1", "voting_method": 2, "withdrawn": ["test2"], "candidates": ["test1", "test2"]}],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
+		{"Withdrawn option not an option", `{"id": 3, "contests": [{"id": "q1", "withdrawn": ["maybe"], "candidates": ["yes", "no"]}],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Redistribute on plurality contest", `{"id": 3, "withdrawal": 2, "contests": [{"id": "q1", "withdrawn": ["no"], "candidates": ["yes", "no"]}],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Redistribute on plurality Election", `{"id": 3, "withdrawal": 2, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Secret Election with withdrawn candidate", `{"id": 3, "secret": true, "withdrawn": ["test2"], "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Create Election with candidate details", `{"id": 3, "details": [{"id": "c1", "name": "Jane Doe", "metadata": {"party": "Green"}}, {"id": "c2", "name": "John Roe"}],"end": { "seconds": 1536525322 }}`, http.StatusCreated, nil},
+		{"Details not matching candidates", `{"id": 3, "candidates": ["c1", "c3"], "details": [{"id": "c1", "name": "Jane Doe"}, {"id": "c2", "name": "John Roe"}],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Details with duplicate id", `{"id": 3, "details": [{"id": "c1", "name": "Jane Doe"}, {"id": "c1", "name": "John Roe"}],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
+		{"Unknown voting method", `{"id": 3, "voting_method": 9, "candidates": ["test1", "test2"],"end": { "seconds": 1536525322 }}`, http.StatusBadRequest, nil},
 	}
+	mgoDal.On("FindOne", mock.Anything, mock.Anything, mock.Anything).Return(mgo.ErrNotFound)
+	mgoDal.On("Insert", mock.Anything, mock.Anything).Return(nil)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &server{
-				mgoDal: mgoDal,
-				logger: log,
+				mgoDal:         mgoDal,
+				logger:         log,
+				electionSecret: make([]byte, 32),
 			}
 			mgoDal.On("Upsert", mock.Anything, mock.Anything, mock.Anything).Return(tt.queryRet).Once()
 
@@ -119,6 +187,75 @@ func Test_server_valid(t *testing.T) {
 	}
 }
 
+func Test_server_valid_ballot(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	log, _ := zap.NewProduction()
+
+	tests := []struct {
+		name       string
+		method     pb.VotingMethod
+		body       string
+		statusCode int
+	}{
+		{"Plurality ballot", pb.VotingMethod_PLURALITY, `{"electionId": 1, "candidate": "test1"}`, http.StatusOK},
+		{"Ranked ballot", pb.VotingMethod_RANKED, `{"electionId": 1, "rankings": ["test2", "test1"]}`, http.StatusOK},
+		{"Ranked ballot on plurality election", pb.VotingMethod_PLURALITY, `{"electionId": 1, "rankings": ["test2", "test1"]}`, http.StatusBadRequest},
+		{"Unknown candidate", pb.VotingMethod_APPROVAL, `{"electionId": 1, "selections": ["test3"]}`, http.StatusBadRequest},
+		{"Score out of range", pb.VotingMethod_SCORE, `{"electionId": 1, "scores": {"test1": 9}}`, http.StatusBadRequest},
+		{"Empty ballot", pb.VotingMethod_SCORE, `{"electionId": 1}`, http.StatusBadRequest},
+		{"Invalid payload", pb.VotingMethod_PLURALITY, ``, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			s := &server{
+				mgoDal: mgoDal,
+				logger: log,
+				isOver: func(end *timestamp.Timestamp) bool { return false },
+			}
+
+			mgoDal.On("FindOne", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				*args.Get(2).(*pb.Election) = pb.Election{Id: 1, Candidates: []string{"test1", "test2"}, VotingMethod: tt.method, MaxScore: 5}
+			}).Once()
+
+			req, err := http.NewRequest("POST", "localhost:9223/election/1/validate", strings.NewReader(tt.body))
+			assert.Nil(t, err, "could not create request")
+			rec := httptest.NewRecorder()
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			s.valid(rec, req)
+			res := rec.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.statusCode, res.StatusCode, "Did not get the same response code")
+		})
+	}
+
+	t.Run("Withdrawn candidate", func(t *testing.T) {
+		mgoDal.ExpectedCalls = nil
+		s := &server{
+			mgoDal: mgoDal,
+			logger: log,
+			isOver: func(end *timestamp.Timestamp) bool { return false },
+		}
+
+		mgoDal.On("FindOne", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			*args.Get(2).(*pb.Election) = pb.Election{Id: 1, Candidates: []string{"test1", "test2"}, Withdrawn: []string{"test2"}, VotingMethod: pb.VotingMethod_APPROVAL}
+		}).Once()
+
+		req, err := http.NewRequest("POST", "localhost:9223/election/1/validate", strings.NewReader(`{"electionId": 1, "selections": ["test1", "test2"]}`))
+		assert.Nil(t, err, "could not create request")
+		rec := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		s.valid(rec, req)
+		res := rec.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, "Did not get the same response code")
+	})
+}
+
 func Test_server_delete(t *testing.T) {
 	mgoDal := &tests.DataAccessLayerMock{}
 	log, _ := zap.NewProduction()
@@ -161,6 +298,917 @@ func Test_server_delete(t *testing.T) {
 	}
 }
 
+func Test_server_commitment(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	log, _ := zap.NewProduction()
+
+	isOverRetFalse := func(end *timestamp.Timestamp) bool {
+		return false
+	}
+	isOverRetTrue := func(end *timestamp.Timestamp) bool {
+		return true
+	}
+
+	tests := []struct {
+		name       string
+		ID         string
+		statusCode int
+		isOverMock func(end *timestamp.Timestamp) bool
+		electRet   error
+		commitRet  error
+	}{
+		{"Commitment published", "1", http.StatusOK, isOverRetTrue, nil, nil},
+		{"Not committed yet", "1", http.StatusConflict, isOverRetTrue, nil, mgo.ErrNotFound},
+		{"Election not over", "1", http.StatusConflict, isOverRetFalse, nil, nil},
+		{"Election not found", "1", http.StatusNotFound, isOverRetTrue, mgo.ErrNotFound, nil},
+		{"Election find fail", "1", http.StatusInternalServerError, isOverRetTrue, errors.New("test error"), nil},
+		{"Commitment find fail", "1", http.StatusInternalServerError, isOverRetTrue, nil, errors.New("test error")},
+		{"Id != int", "test", http.StatusBadRequest, isOverRetTrue, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			s := &server{
+				Collection: "election",
+				CommitColl: "commitment",
+				mgoDal:     mgoDal,
+				logger:     log,
+				isOver:     tt.isOverMock,
+			}
+
+			mgoDal.On("FindOne", "election", mock.Anything, mock.Anything).Return(tt.electRet).Once()
+			mgoDal.On("FindOne", "commitment", mock.Anything, mock.Anything).Return(tt.commitRet).Once()
+
+			req, err := http.NewRequest("GET", "localhost:9223/election/commitment", nil)
+			assert.Nil(t, err, "could not create request")
+			rec := httptest.NewRecorder()
+
+			vars := map[string]string{
+				"id": tt.ID,
+			}
+			req = mux.SetURLVars(req, vars)
+
+			s.commitment(rec, req)
+			res := rec.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.statusCode, res.StatusCode, "Did not get the same response code")
+		})
+	}
+}
+
+func Test_server_commit(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	log, _ := zap.NewProduction()
+	testErr := errors.New("test error")
+
+	tests := []struct {
+		name      string
+		votesRet  error
+		proofRet  error
+		commitRet error
+		updateRet error
+		wantErr   error
+		proofs    int
+	}{
+		{"Commit ballots", nil, nil, nil, nil, nil, 2},
+		{"Ballots find fail", testErr, nil, nil, nil, testErr, 0},
+		{"Proof store fail", nil, testErr, nil, nil, testErr, 1},
+		{"Commitment store fail", nil, nil, testErr, nil, testErr, 2},
+		{"Election update fail", nil, nil, nil, testErr, testErr, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			mgoDal.Calls = nil
+			s := &server{
+				Collection: "election",
+				CommitColl: "commitment",
+				ProofColl:  "proof",
+				VoteColl:   "vote",
+				mgoDal:     mgoDal,
+				logger:     log,
+			}
+
+			var (
+				proofs     []*pb.InclusionProof
+				commitment *pb.Commitment
+			)
+			mgoDal.On("FindAll", "vote", mock.Anything, mock.Anything).Return(tt.votesRet).Run(func(args mock.Arguments) {
+				votes := args.Get(2).(*[]pb.Vote)
+				*votes = []pb.Vote{{ElectionId: 1, Candidate: "test2", Receipt: "b"}, {ElectionId: 1, Candidate: "test1", Receipt: "a"}}
+			}).Once()
+			mgoDal.On("Upsert", "proof", mock.Anything, mock.Anything).Return(tt.proofRet).Run(func(args mock.Arguments) {
+				proofs = append(proofs, args.Get(2).(*pb.InclusionProof))
+			})
+			mgoDal.On("Upsert", "commitment", mock.Anything, mock.Anything).Return(tt.commitRet).Run(func(args mock.Arguments) {
+				commitment = args.Get(2).(*pb.Commitment)
+			}).Once()
+			mgoDal.On("Update", "election", bson.M{elecIDKey: int32(1)}, bson.M{"$set": bson.M{committedKey: true}}).Return(tt.updateRet).Once()
+
+			err := s.commit(&pb.Election{Id: 1})
+			assert.Equal(t, tt.wantErr, err)
+			assert.Len(t, proofs, tt.proofs)
+			if tt.wantErr != nil {
+				return
+			}
+
+			assert.Equal(t, int32(2), commitment.GetBallots())
+			root, _ := hex.DecodeString(commitment.GetRoot())
+			for i, p := range proofs {
+				assert.Equal(t, int32(i), p.GetIndex())
+				assert.Equal(t, commitment.GetRoot(), p.GetRoot())
+				leaf, _ := hex.DecodeString(p.GetBallot())
+				var path []merkle.Step
+				for _, step := range p.GetPath() {
+					h, _ := hex.DecodeString(step.GetHash())
+					path = append(path, merkle.Step{Hash: h, Left: step.GetLeft()})
+				}
+				assert.True(t, merkle.Verify(leaf, path, root), p.GetReceipt())
+			}
+			assert.Equal(t, "a", proofs[0].GetReceipt())
+		})
+	}
+}
+
+func Test_server_results(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	log, _ := zap.NewProduction()
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	votes := []pb.Vote{
+		{ElectionId: 1, Receipt: "r1", Candidate: "test1", Rankings: []string{"test1"}},
+		{ElectionId: 1, Receipt: "r2", Candidate: "test2", Rankings: []string{"test2"}},
+		{ElectionId: 1, Receipt: "r3", Candidate: "test1", Rankings: []string{"test1", "test2"}},
+	}
+
+	isOverRetFalse := func(end *timestamp.Timestamp) bool {
+		return false
+	}
+	isOverRetTrue := func(end *timestamp.Timestamp) bool {
+		return true
+	}
+
+	tests := []struct {
+		name       string
+		ID         string
+		statusCode int
+		isOverMock func(end *timestamp.Timestamp) bool
+		electRet   error
+		resultRet  error
+		votesRet   error
+		insertRet  error
+		method     pb.VotingMethod
+		rounds     int
+		committed  bool
+	}{
+		{"Sign results", "1", http.StatusOK, isOverRetTrue, nil, mgo.ErrNotFound, nil, nil, pb.VotingMethod_PLURALITY, 0, true},
+		{"Sign ranked results", "1", http.StatusOK, isOverRetTrue, nil, mgo.ErrNotFound, nil, nil, pb.VotingMethod_RANKED, 1, true},
+		{"Election not over", "1", http.StatusConflict, isOverRetFalse, nil, nil, nil, nil, pb.VotingMethod_PLURALITY, 0, true},
+		{"Election not found", "1", http.StatusNotFound, isOverRetTrue, mgo.ErrNotFound, nil, nil, nil, pb.VotingMethod_PLURALITY, 0, true},
+		{"Election find fail", "1", http.StatusInternalServerError, isOverRetTrue, errors.New("test error"), nil, nil, nil, pb.VotingMethod_PLURALITY, 0, true},
+		{"Results find fail", "1", http.StatusInternalServerError, isOverRetTrue, nil, errors.New("test error"), nil, nil, pb.VotingMethod_PLURALITY, 0, true},
+		{"Ballots find fail", "1", http.StatusInternalServerError, isOverRetTrue, nil, mgo.ErrNotFound, errors.New("test error"), nil, pb.VotingMethod_PLURALITY, 0, true},
+		{"Insert fail", "1", http.StatusInternalServerError, isOverRetTrue, nil, mgo.ErrNotFound, nil, errors.New("test error"), pb.VotingMethod_PLURALITY, 0, true},
+		{"Ballots not committed", "1", http.StatusConflict, isOverRetTrue, nil, nil, nil, nil, pb.VotingMethod_PLURALITY, 0, false},
+		{"Id != int", "test", http.StatusBadRequest, isOverRetTrue, nil, nil, nil, nil, pb.VotingMethod_PLURALITY, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			s := &server{
+				Collection: "election",
+				CommitColl: "commit",
+				ProofColl:  "proof",
+				ResultColl: "results",
+				VoteColl:   "vote",
+				signingKey: priv,
+				mgoDal:     mgoDal,
+				logger:     log,
+				isOver:     tt.isOverMock,
+			}
+
+			mgoDal.On("FindOne", "election", mock.Anything, mock.Anything).Return(tt.electRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*pb.Election) = pb.Election{Id: 1, Candidates: []string{"test1", "test2"}, VotingMethod: tt.method, Committed: tt.committed}
+			}).Once()
+			mgoDal.On("FindOne", "results", mock.Anything, mock.Anything).Return(tt.resultRet).Once()
+			commitVotes(mgoDal, votes)
+			mgoDal.On("FindAll", "vote", mock.Anything, mock.Anything).Return(tt.votesRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*[]pb.Vote) = votes
+			}).Once()
+			mgoDal.On("Insert", "results", mock.Anything).Return(tt.insertRet).Once()
+
+			req, err := http.NewRequest("GET", "localhost:9223/election/results", nil)
+			assert.Nil(t, err, "could not create request")
+			rec := httptest.NewRecorder()
+			req = mux.SetURLVars(req, map[string]string{"id": tt.ID})
+
+			s.results(rec, req)
+			res := rec.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.statusCode, res.StatusCode, "Did not get the same response code")
+			if tt.statusCode != http.StatusOK {
+				return
+			}
+
+			var signed pb.SignedResults
+			assert.Nil(t, json.NewDecoder(res.Body).Decode(&signed))
+			assert.Nil(t, sign.Verify(pub, &signed))
+			assert.Equal(t, int64(3), signed.GetResults().GetBallots())
+			assert.Equal(t, "test1", signed.GetResults().GetTallies()[0].GetCandidate())
+			assert.Len(t, signed.GetResults().GetRounds(), tt.rounds)
+		})
+	}
+}
+
+func Test_server_committedVotes(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	s := &server{CommitColl: "commit", ProofColl: "proof", VoteColl: "vote", mgoDal: mgoDal}
+	election := &pb.Election{Id: 1}
+	votes := []pb.Vote{{ElectionId: 1, Receipt: "r1", Candidate: "test1"}, {ElectionId: 1, Receipt: "r2", Candidate: "test2"}}
+	late := pb.Vote{ElectionId: 1, Receipt: "r3", Candidate: "test1"}
+	tree, _ := merkle.Ballots(append([]pb.Vote(nil), votes...))
+	root := hex.EncodeToString(tree.Root())
+
+	tests := []struct {
+		name      string
+		root      string
+		commitRet error
+		proofRet  error
+		votesRet  error
+		stored    []pb.Vote
+		want      []pb.Vote
+		wantErr   bool
+	}{
+		{"Committed ballots", root, nil, nil, nil, votes, votes, false},
+		{"Ballot stored after the commitment", root, nil, nil, nil, append([]pb.Vote{late}, votes...), votes, false},
+		{"Ballots do not match the root", "00", nil, nil, nil, votes, nil, true},
+		{"Committed ballot missing", root, nil, nil, nil, votes[:1], nil, true},
+		{"Commitment find fail", root, errors.New("test error"), nil, nil, votes, nil, true},
+		{"Proofs find fail", root, nil, errors.New("test error"), nil, votes, nil, true},
+		{"Ballots find fail", root, nil, nil, errors.New("test error"), votes, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			mgoDal.On("FindOne", "commit", bson.M{voteElecIDKey: int32(1)}, mock.Anything).Return(tt.commitRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*pb.Commitment) = pb.Commitment{ElectionId: 1, Root: tt.root, Ballots: 2}
+			}).Once()
+			mgoDal.On("FindAll", "proof", bson.M{voteElecIDKey: int32(1)}, mock.Anything).Return(tt.proofRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*[]pb.InclusionProof) = []pb.InclusionProof{{Receipt: "r1"}, {Receipt: "r2"}}
+			}).Once()
+			mgoDal.On("FindAll", "vote", bson.M{voteElecIDKey: int32(1)}, mock.Anything).Return(tt.votesRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*[]pb.Vote) = append([]pb.Vote(nil), tt.stored...)
+			}).Once()
+
+			got, err := s.committedVotes(election)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Len(t, got, len(tt.want))
+			for i := range got {
+				assert.Equal(t, tt.want[i].GetReceipt(), got[i].GetReceipt())
+			}
+		})
+	}
+}
+
+func Test_server_decryptTally(t *testing.T) {
+	secret := make([]byte, 32)
+	rand.Read(secret)
+	s := &server{electionSecret: secret}
+	key, _ := s.electionKey(1)
+
+	var votes []pb.Vote
+	for _, choices := range [][]int64{{1, 0, 0}, {0, 0, 1}, {1, 0, 0}} {
+		cs, _, _, _ := elgamal.EncryptChoices(rand.Reader, key.Public(), choices)
+		votes = append(votes, pb.Vote{ElectionId: 1, Encrypted: cs})
+	}
+	short, _, _, _ := elgamal.EncryptChoices(rand.Reader, key.Public(), []int64{1, 0})
+	election := &pb.Election{Id: 1, Secret: true, Candidates: []string{"test1", "test2", "test3"}}
+
+	tests := []struct {
+		name    string
+		votes   []pb.Vote
+		secret  []byte
+		want    []*pb.Tally
+		ballots int64
+		wantErr bool
+	}{
+		{"Decrypt sums", votes, secret, []*pb.Tally{{Candidate: "test1", Votes: 2}, {Candidate: "test3", Votes: 1}, {Candidate: "test2"}}, 3, false},
+		{"No ballots", nil, secret, []*pb.Tally{{Candidate: "test1"}, {Candidate: "test2"}, {Candidate: "test3"}}, 0, false},
+		{"No election key", votes, nil, nil, 0, true},
+		{"Ballots do not match candidates", []pb.Vote{{Encrypted: short}}, secret, nil, 0, true},
+		{"Ballots of different lengths", append([]pb.Vote{{Encrypted: short}}, votes...), secret, nil, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.electionSecret = tt.secret
+
+			tallies, ballots, err := s.decryptTally(election, tt.votes)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, tallies)
+			assert.Equal(t, tt.ballots, ballots)
+		})
+	}
+}
+
+// commitVotes mocks the commitment of the ballots and the inclusion proofs
+// of their receipts
+func commitVotes(mgoDal *tests.DataAccessLayerMock, votes []pb.Vote) {
+	tree, _ := merkle.Ballots(append([]pb.Vote(nil), votes...))
+	mgoDal.On("FindOne", "commit", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(2).(*pb.Commitment) = pb.Commitment{Root: hex.EncodeToString(tree.Root()), Ballots: int32(len(votes))}
+	})
+	mgoDal.On("FindAll", "proof", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		proofs := make([]pb.InclusionProof, 0, len(votes))
+		for _, v := range votes {
+			proofs = append(proofs, pb.InclusionProof{Receipt: v.GetReceipt()})
+		}
+		*args.Get(2).(*[]pb.InclusionProof) = proofs
+	})
+}
+
+func Test_server_startRunoff(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	log, _ := zap.NewProduction()
+	dup := &mgo.LastError{Code: 11000}
+	split := []pb.Vote{{Candidate: "test1"}, {Candidate: "test2"}, {Candidate: "test1"}, {Candidate: "test3"}}
+	clear := []pb.Vote{{Candidate: "test1"}, {Candidate: "test2"}, {Candidate: "test1"}, {Candidate: "test1"}}
+	release := bson.M{"$set": bson.M{decidedKey: false, runoffIDKey: 0}}
+
+	tests := []struct {
+		name      string
+		votes     []pb.Vote
+		tieBreak  pb.TieBreak
+		votesRet  error
+		lastRet   error
+		claimRet  error
+		insertRet []error
+		claims    int
+		released  bool
+		runoff    *pb.Election
+		wantErr   bool
+	}{
+		{"Start runoff", split, pb.TieBreak_DECLARE, nil, nil, nil, []error{nil}, 1, false, &pb.Election{Id: 8, Candidates: []string{"test1", "test2", "test3"}, Parent: 1}, false},
+		{"Tie for second broken by lot", split, pb.TieBreak_LOT, nil, nil, nil, []error{nil}, 1, false, &pb.Election{Id: 8, Candidates: []string{"test1", "test3"}, Parent: 1, TieBreak: pb.TieBreak_LOT, Lot: []string{"test3", "test1"}}, false},
+		{"First election", split, pb.TieBreak_DECLARE, nil, mgo.ErrNotFound, nil, []error{nil}, 1, false, &pb.Election{Id: 1, Candidates: []string{"test1", "test2", "test3"}, Parent: 1}, false},
+		{"Majority reached", clear, pb.TieBreak_DECLARE, nil, nil, nil, nil, 1, false, nil, false},
+		{"No ballots", nil, pb.TieBreak_DECLARE, nil, nil, nil, nil, 1, false, nil, false},
+		{"Claimed elsewhere", split, pb.TieBreak_DECLARE, nil, nil, mgo.ErrNotFound, nil, 1, false, nil, false},
+		{"Id taken", split, pb.TieBreak_DECLARE, nil, nil, nil, []error{dup, nil}, 2, false, &pb.Election{Id: 8, Candidates: []string{"test1", "test2", "test3"}, Parent: 1}, false},
+		{"Ballots find fail", split, pb.TieBreak_DECLARE, errors.New("test error"), nil, nil, nil, 0, false, nil, true},
+		{"Last id find fail", split, pb.TieBreak_DECLARE, nil, errors.New("test error"), nil, nil, 0, false, nil, true},
+		{"Insert fail releases the claim", split, pb.TieBreak_DECLARE, nil, nil, nil, []error{errors.New("test error")}, 2, true, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			mgoDal.Calls = nil
+			s := &server{
+				Collection: "election",
+				CommitColl: "commit",
+				ProofColl:  "proof",
+				VoteColl:   "vote",
+				mgoDal:     mgoDal,
+				logger:     log,
+			}
+
+			commitVotes(mgoDal, tt.votes)
+			mgoDal.On("FindFirst", "election", bson.M{}, "-"+elecIDKey, mock.Anything).Return(tt.lastRet).Run(func(args mock.Arguments) {
+				if tt.lastRet == nil {
+					*args.Get(3).(*pb.Election) = pb.Election{Id: 7}
+				}
+			})
+			mgoDal.On("FindAll", "vote", mock.Anything, mock.Anything).Return(tt.votesRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*[]pb.Vote) = tt.votes
+			})
+			mgoDal.On("Update", "election", mock.Anything, mock.Anything).Return(tt.claimRet)
+			var inserted *pb.Election
+			for _, ret := range tt.insertRet {
+				mgoDal.On("Insert", "election", mock.Anything).Return(ret).Run(func(args mock.Arguments) {
+					inserted = args.Get(1).(*pb.Election)
+				}).Once()
+			}
+
+			parent := &pb.Election{Id: 1, Candidates: []string{"test1", "test2", "test3"}, TieBreak: tt.tieBreak, Runoff: &pb.RunoffPolicy{Majority: 0.6, Duration: 3600}}
+			if tt.tieBreak == pb.TieBreak_LOT {
+				parent.Lot = []string{"test3", "test2", "test1"}
+			}
+			err := s.startRunoff(parent)
+			assert.Equal(t, tt.wantErr, err != nil)
+			mgoDal.AssertNumberOfCalls(t, "Update", tt.claims)
+			mgoDal.AssertNumberOfCalls(t, "Insert", len(tt.insertRet))
+			if tt.released {
+				mgoDal.AssertCalled(t, "Update", "election", bson.M{elecIDKey: int32(1), runoffIDKey: int32(8), decidedKey: true}, release)
+			}
+			if tt.runoff == nil {
+				return
+			}
+			assert.Equal(t, tt.runoff.GetId(), inserted.GetId())
+			assert.Equal(t, tt.runoff.GetCandidates(), inserted.GetCandidates())
+			assert.Equal(t, tt.runoff.GetParent(), inserted.GetParent())
+			assert.Equal(t, tt.runoff.GetTieBreak(), inserted.GetTieBreak())
+			assert.Equal(t, tt.runoff.GetLot(), inserted.GetLot())
+			assert.Equal(t, pb.VotingMethod_PLURALITY, inserted.GetVotingMethod())
+			end, _ := ptypes.Timestamp(inserted.GetEnd())
+			start, _ := ptypes.Timestamp(inserted.GetStart())
+			assert.Equal(t, time.Hour, end.Sub(start).Round(time.Minute))
+		})
+	}
+}
+
+func Test_server_startRunoffs(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	log, _ := zap.NewProduction()
+	split := []pb.Vote{{Candidate: "test1"}, {Candidate: "test2"}, {Candidate: "test1"}, {Candidate: "test3"}}
+	policy := &pb.RunoffPolicy{Majority: 0.6, Duration: 3600}
+	s := &server{
+		Collection: "election",
+		CommitColl: "commit",
+		ProofColl:  "proof",
+		VoteColl:   "vote",
+		mgoDal:     mgoDal,
+		logger:     log,
+		isOver: func(end *timestamp.Timestamp) bool {
+			return end.GetSeconds() > 0
+		},
+	}
+
+	pending := mock.MatchedBy(func(q bson.M) bool { _, ok := q[majorityKey]; return ok })
+	mgoDal.On("FindAll", "election", pending, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(2).(*[]pb.Election) = []pb.Election{
+			{Id: 1, End: &timestamp.Timestamp{Seconds: 1}, Candidates: []string{"test1", "test2", "test3"}, Runoff: policy},
+			{Id: 2, End: &timestamp.Timestamp{}, Candidates: []string{"test1", "test2", "test3"}, Runoff: policy},
+			{Id: 3, End: &timestamp.Timestamp{Seconds: 1}, Candidates: []string{"test1", "test2", "test3"}, Runoff: policy},
+		}
+	}).Once()
+	commitVotes(mgoDal, split)
+	mgoDal.On("FindAll", "vote", bson.M{voteElecIDKey: int32(1)}, mock.Anything).Return(errors.New("test error")).Once()
+	mgoDal.On("FindAll", "vote", bson.M{voteElecIDKey: int32(3)}, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(2).(*[]pb.Vote) = split
+	}).Once()
+	mgoDal.On("FindFirst", "election", bson.M{}, "-"+elecIDKey, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(3).(*pb.Election) = pb.Election{Id: 7}
+	})
+	mgoDal.On("Update", "election", mock.Anything, mock.Anything).Return(nil).Once()
+	var inserted *pb.Election
+	mgoDal.On("Insert", "election", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		inserted = args.Get(1).(*pb.Election)
+	}).Once()
+
+	assert.Nil(t, s.startRunoffs())
+	mgoDal.AssertExpectations(t)
+	assert.Equal(t, int32(3), inserted.GetParent())
+
+	mgoDal.On("FindAll", "election", pending, mock.Anything).Return(errors.New("test error")).Once()
+	assert.NotNil(t, s.startRunoffs())
+}
+
+func Test_server_electionKey(t *testing.T) {
+	secret := make([]byte, 32)
+	rand.Read(secret)
+	s := &server{electionSecret: secret}
+
+	a, err := s.electionKey(1)
+	assert.Nil(t, err)
+	b, err := s.electionKey(1)
+	assert.Nil(t, err)
+	assert.Equal(t, a.Public(), b.Public())
+
+	s.electionSecret = nil
+	_, err = s.electionKey(1)
+	assert.NotNil(t, err)
+}
+
+func Test_server_codes(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	log, _ := zap.NewProduction()
+
+	tests := []struct {
+		name       string
+		ID         string
+		count      string
+		statusCode int
+		inviteOnly bool
+		electRet   error
+		insertRet  error
+	}{
+		{"Create codes", "1", "3", http.StatusCreated, true, nil, nil},
+		{"Not invite only", "1", "3", http.StatusBadRequest, false, nil, nil},
+		{"Election not found", "1", "3", http.StatusNotFound, true, mgo.ErrNotFound, nil},
+		{"Election find fail", "1", "3", http.StatusInternalServerError, true, errors.New("test error"), nil},
+		{"Insert fail", "1", "3", http.StatusInternalServerError, true, nil, errors.New("test error")},
+		{"Count != int", "1", "test", http.StatusBadRequest, true, nil, nil},
+		{"Count = 0", "1", "0", http.StatusBadRequest, true, nil, nil},
+		{"Count above max", "1", "100001", http.StatusBadRequest, true, nil, nil},
+		{"Id != int", "test", "3", http.StatusBadRequest, true, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stored []interface{}
+			mgoDal.ExpectedCalls = nil
+			s := &server{
+				Collection: "election",
+				CodeColl:   "code",
+				mgoDal:     mgoDal,
+				logger:     log,
+			}
+
+			mgoDal.On("FindOne", "election", mock.Anything, mock.Anything).Return(tt.electRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*pb.Election) = pb.Election{Id: 1, InviteOnly: tt.inviteOnly}
+			}).Once()
+			mgoDal.On("InsertAll", "code", mock.Anything).Return(tt.insertRet).Run(func(args mock.Arguments) {
+				stored = args.Get(1).([]interface{})
+			}).Once()
+
+			req, err := http.NewRequest("POST", "localhost:9223/election/codes?count="+tt.count, nil)
+			assert.Nil(t, err, "could not create request")
+			rec := httptest.NewRecorder()
+			req = mux.SetURLVars(req, map[string]string{"id": tt.ID})
+
+			s.codes(rec, req)
+			res := rec.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.statusCode, res.StatusCode, "Did not get the same response code")
+			if tt.statusCode != http.StatusCreated {
+				return
+			}
+
+			rows, err := csv.NewReader(res.Body).ReadAll()
+			assert.Nil(t, err)
+			assert.Len(t, rows, 4)
+			assert.Len(t, stored, 3)
+			for i, row := range rows[1:] {
+				assert.Equal(t, "1", row[0])
+				assert.Equal(t, invite.Hash(row[1]), stored[i].(*pb.VotingCode).GetHash(), "stored hash does not match exported code")
+			}
+		})
+	}
+}
+
+func Test_server_analysis(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	log, _ := zap.NewProduction()
+
+	tests := []struct {
+		name       string
+		ID         string
+		k          string
+		statusCode int
+		method     pb.VotingMethod
+		over       bool
+		electRet   error
+		votesRet   error
+		approval   string
+	}{
+		{"Compare methods", "1", "", http.StatusOK, pb.VotingMethod_RANKED, true, nil, nil, "approval-top-2"},
+		{"Compare methods with k", "1", "3", http.StatusOK, pb.VotingMethod_SCHULZE, true, nil, nil, "approval-top-3"},
+		{"Not ranked", "1", "", http.StatusBadRequest, pb.VotingMethod_PLURALITY, true, nil, nil, ""},
+		{"k above candidates", "1", "4", http.StatusBadRequest, pb.VotingMethod_RANKED, true, nil, nil, ""},
+		{"k != int", "1", "test", http.StatusBadRequest, pb.VotingMethod_RANKED, true, nil, nil, ""},
+		{"Election not over", "1", "", http.StatusConflict, pb.VotingMethod_RANKED, false, nil, nil, ""},
+		{"Election not found", "1", "", http.StatusNotFound, pb.VotingMethod_RANKED, true, mgo.ErrNotFound, nil, ""},
+		{"Election find fail", "1", "", http.StatusInternalServerError, pb.VotingMethod_RANKED, true, errors.New("test error"), nil, ""},
+		{"Ballots find fail", "1", "", http.StatusInternalServerError, pb.VotingMethod_RANKED, true, nil, errors.New("test error"), ""},
+		{"Id != int", "test", "", http.StatusBadRequest, pb.VotingMethod_RANKED, true, nil, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			s := &server{
+				Collection: "election",
+				VoteColl:   "vote",
+				mgoDal:     mgoDal,
+				logger:     log,
+				isOver:     func(end *timestamp.Timestamp) bool { return tt.over },
+			}
+
+			mgoDal.On("FindOne", "election", mock.Anything, mock.Anything).Return(tt.electRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*pb.Election) = pb.Election{Id: 1, Candidates: []string{"test1", "test2", "test3"}, VotingMethod: tt.method}
+			}).Once()
+			mgoDal.On("FindAll", "vote", mock.Anything, mock.Anything).Return(tt.votesRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*[]pb.Vote) = []pb.Vote{
+					{ElectionId: 1, Rankings: []string{"test1", "test2"}},
+					{ElectionId: 1, Rankings: []string{"test2", "test1"}},
+					{ElectionId: 1, Rankings: []string{"test1"}},
+				}
+			}).Once()
+
+			req, err := http.NewRequest("GET", "localhost:9223/election/1/analysis?k="+tt.k, nil)
+			assert.Nil(t, err, "could not create request")
+			rec := httptest.NewRecorder()
+			req = mux.SetURLVars(req, map[string]string{"id": tt.ID})
+
+			s.analysis(rec, req)
+			res := rec.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.statusCode, res.StatusCode, "Did not get the same response code")
+			if tt.statusCode != http.StatusOK {
+				return
+			}
+
+			var analysis pb.Analysis
+			assert.Nil(t, json.NewDecoder(res.Body).Decode(&analysis))
+			assert.Equal(t, int32(1), analysis.GetElectionId())
+			assert.Equal(t, int64(3), analysis.GetBallots())
+			assert.Len(t, analysis.GetOutcomes(), 5)
+			assert.Equal(t, tt.approval, analysis.GetOutcomes()[4].GetMethod())
+			assert.Equal(t, "test1", analysis.GetOutcomes()[0].GetWinner())
+		})
+	}
+}
+
+func Test_server_ballot(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	log, _ := zap.NewProduction()
+
+	tests := []struct {
+		name       string
+		ID         string
+		voter      string
+		order      pb.BallotOrder
+		statusCode int
+		electRet   error
+		want       []string
+	}{
+		{"Fixed ballot", "1", "", pb.BallotOrder_FIXED, http.StatusOK, nil, []string{"test3", "test1", "test2"}},
+		{"Alphabetical ballot", "1", "", pb.BallotOrder_ALPHABETICAL, http.StatusOK, nil, []string{"test1", "test2", "test3"}},
+		{"Shuffled ballot", "1", "voter", pb.BallotOrder_SHUFFLE, http.StatusOK, nil, nil},
+		{"Shuffled ballot without voter", "1", "", pb.BallotOrder_SHUFFLE, http.StatusBadRequest, nil, nil},
+		{"Election not found", "1", "", pb.BallotOrder_FIXED, http.StatusNotFound, mgo.ErrNotFound, nil},
+		{"Election find fail", "1", "", pb.BallotOrder_FIXED, http.StatusInternalServerError, errors.New("test error"), nil},
+		{"Id != int", "test", "", pb.BallotOrder_FIXED, http.StatusBadRequest, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			s := &server{Collection: "election", mgoDal: mgoDal, logger: log}
+			election := pb.Election{Id: 1, Candidates: []string{"test3", "test1", "test2"}, BallotOrder: tt.order, BallotSeed: 42}
+
+			mgoDal.On("FindOne", "election", mock.Anything, mock.Anything).Return(tt.electRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*pb.Election) = election
+			}).Once()
+
+			req, err := http.NewRequest("GET", "localhost:9223/election/1/ballot?voter="+tt.voter, nil)
+			assert.Nil(t, err, "could not create request")
+			rec := httptest.NewRecorder()
+			req = mux.SetURLVars(req, map[string]string{"id": tt.ID})
+
+			s.ballot(rec, req)
+			res := rec.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.statusCode, res.StatusCode, "Did not get the same response code")
+			if tt.statusCode != http.StatusOK {
+				return
+			}
+
+			var b pb.Ballot
+			assert.Nil(t, json.NewDecoder(res.Body).Decode(&b))
+			assert.Equal(t, int32(1), b.GetElectionId())
+			assert.Equal(t, tt.voter, b.GetVoter())
+			if tt.want == nil {
+				tt.want = ballot.Order(&election, tt.voter).GetCandidates()
+			}
+			assert.Equal(t, tt.want, b.GetCandidates())
+		})
+	}
+
+	t.Run("Localized ballot", func(t *testing.T) {
+		mgoDal.ExpectedCalls = nil
+		s := &server{Collection: "election", Locale: "en", mgoDal: mgoDal, logger: log}
+		election := pb.Election{
+			Id:         1,
+			Title:      "Board election",
+			Candidates: []string{"c1"},
+			Details:    []*pb.Candidate{{Id: "c1", Name: "Jane"}},
+			Labels:     map[string]*pb.Labels{"fr": {Title: "Élection du conseil", Candidates: map[string]string{"c1": "Jeanne"}}},
+		}
+		mgoDal.On("FindOne", "election", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			*args.Get(2).(*pb.Election) = election
+		})
+
+		req, err := http.NewRequest("GET", "localhost:9223/election/1/ballot", nil)
+		assert.Nil(t, err, "could not create request")
+		req.Header.Set("Accept-Language", "fr-CA, en;q=0.5")
+		rec := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+		s.ballot(rec, req)
+		res := rec.Result()
+		defer res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "fr", res.Header.Get("Content-Language"))
+		var b pb.Ballot
+		assert.Nil(t, json.NewDecoder(res.Body).Decode(&b))
+		assert.Equal(t, "Élection du conseil", b.GetTitle())
+		assert.Equal(t, "Jeanne", b.GetDetails()[0].GetName())
+	})
+}
+
+func Test_server_withdraw(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	log, _ := zap.NewProduction()
+
+	tests := []struct {
+		name       string
+		ID         string
+		candidate  string
+		secret     bool
+		over       bool
+		statusCode int
+		electRet   error
+		updateRet  error
+		withdrawn  []string
+	}{
+		{"Withdraw candidate", "1", "test2", false, false, http.StatusOK, nil, nil, []string{"test3", "test2"}},
+		{"Withdraw again", "1", "test3", false, false, http.StatusOK, nil, nil, []string{"test3"}},
+		{"Unknown candidate", "1", "test4", false, false, http.StatusBadRequest, nil, nil, nil},
+		{"Secret election", "1", "test2", true, false, http.StatusBadRequest, nil, nil, nil},
+		{"Election over", "1", "test2", false, true, http.StatusConflict, nil, nil, nil},
+		{"Election not found", "1", "test2", false, false, http.StatusNotFound, mgo.ErrNotFound, nil, nil},
+		{"Election find fail", "1", "test2", false, false, http.StatusInternalServerError, errors.New("test error"), nil, nil},
+		{"Update fail", "1", "test2", false, false, http.StatusInternalServerError, nil, errors.New("test error"), nil},
+		{"Id != int", "test", "test2", false, false, http.StatusBadRequest, nil, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			s := &server{
+				Collection:        "election",
+				mgoDal:            mgoDal,
+				logger:            log,
+				isOver:            func(end *timestamp.Timestamp) bool { return tt.over },
+				containsCandidate: containsCandidate,
+			}
+
+			mgoDal.On("FindOne", "election", mock.Anything, mock.Anything).Return(tt.electRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*pb.Election) = pb.Election{Id: 1, Secret: tt.secret, Candidates: []string{"test1", "test2", "test3"}, Withdrawn: []string{"test3"}}
+			}).Once()
+			mgoDal.On("Update", "election", bson.M{"id": int64(1)}, bson.M{"$addToSet": bson.M{"withdrawn": tt.candidate}}).Return(tt.updateRet).Once()
+
+			req, err := http.NewRequest("POST", "localhost:9223/election/1/withdraw?candidate="+tt.candidate, nil)
+			assert.Nil(t, err, "could not create request")
+			rec := httptest.NewRecorder()
+			req = mux.SetURLVars(req, map[string]string{"id": tt.ID})
+
+			s.withdraw(rec, req)
+			res := rec.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.statusCode, res.StatusCode, "Did not get the same response code")
+			if tt.statusCode != http.StatusOK {
+				return
+			}
+
+			var election pb.Election
+			assert.Nil(t, json.NewDecoder(res.Body).Decode(&election))
+			assert.Equal(t, tt.withdrawn, election.GetWithdrawn())
+		})
+	}
+
+	contests := []struct {
+		name       string
+		contest    string
+		option     string
+		withdrawal pb.Withdrawal
+		statusCode int
+		withdrawn  []string
+	}{
+		{"Withdraw contest option", "q1", "maybe", pb.Withdrawal_VOID, http.StatusOK, []string{"maybe"}},
+		{"Unknown contest", "q2", "maybe", pb.Withdrawal_VOID, http.StatusBadRequest, nil},
+		{"Unknown option", "q1", "test1", pb.Withdrawal_VOID, http.StatusBadRequest, nil},
+		{"Redistribute on plurality contest", "q1", "maybe", pb.Withdrawal_REDISTRIBUTE, http.StatusBadRequest, nil},
+	}
+	for _, tt := range contests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			s := &server{
+				Collection:        "election",
+				mgoDal:            mgoDal,
+				logger:            log,
+				isOver:            func(end *timestamp.Timestamp) bool { return false },
+				containsCandidate: containsCandidate,
+			}
+
+			mgoDal.On("FindOne", "election", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				*args.Get(2).(*pb.Election) = pb.Election{Id: 1, Withdrawal: tt.withdrawal, Contests: []*pb.Contest{{Id: "q1", Candidates: []string{"yes", "no", "maybe"}}}}
+			}).Once()
+			mgoDal.On("Update", "election", bson.M{"id": int64(1), "contests.id": tt.contest}, bson.M{"$addToSet": bson.M{"contests.$.withdrawn": tt.option}}).Return(nil).Once()
+
+			req, err := http.NewRequest("POST", "localhost:9223/election/1/withdraw?contest="+tt.contest+"&candidate="+tt.option, nil)
+			assert.Nil(t, err, "could not create request")
+			rec := httptest.NewRecorder()
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			s.withdraw(rec, req)
+			res := rec.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.statusCode, res.StatusCode, "Did not get the same response code")
+			if tt.statusCode != http.StatusOK {
+				return
+			}
+
+			var election pb.Election
+			assert.Nil(t, json.NewDecoder(res.Body).Decode(&election))
+			assert.Nil(t, election.GetWithdrawn())
+			assert.Equal(t, tt.withdrawn, election.GetContests()[0].GetWithdrawn())
+		})
+	}
+}
+
+func Test_server_weights(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	log, _ := zap.NewProduction()
+
+	tests := []struct {
+		name       string
+		ID         string
+		body       string
+		statusCode int
+		weighted   bool
+		over       bool
+		electRet   error
+		upsertRet  error
+		stored     int
+	}{
+		{"Import weights", "1", "voter,weight\nv1,100\nv2,5\n", http.StatusCreated, true, false, nil, nil, 2},
+		{"Import without header", "1", "v1,100\n", http.StatusCreated, true, false, nil, nil, 1},
+		{"Not weighted", "1", "v1,100\n", http.StatusBadRequest, false, false, nil, nil, 0},
+		{"Election over", "1", "v1,100\n", http.StatusConflict, true, true, nil, nil, 0},
+		{"Election not found", "1", "v1,100\n", http.StatusNotFound, true, false, mgo.ErrNotFound, nil, 0},
+		{"Election find fail", "1", "v1,100\n", http.StatusInternalServerError, true, false, errors.New("test error"), nil, 0},
+		{"Upsert fail", "1", "v1,100\n", http.StatusInternalServerError, true, false, nil, errors.New("test error"), 1},
+		{"Weight != int", "1", "v1,test\n", http.StatusBadRequest, true, false, nil, nil, 0},
+		{"Weight = 0", "1", "v1,0\n", http.StatusBadRequest, true, false, nil, nil, 0},
+		{"Missing column", "1", "v1\n", http.StatusBadRequest, true, false, nil, nil, 0},
+		{"Empty body", "1", "", http.StatusBadRequest, true, false, nil, nil, 0},
+		{"Id != int", "test", "v1,100\n", http.StatusBadRequest, true, false, nil, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stored []*pb.VoterWeight
+			mgoDal.ExpectedCalls = nil
+			s := &server{
+				Collection: "election",
+				WeightColl: "weight",
+				mgoDal:     mgoDal,
+				logger:     log,
+				isOver:     func(end *timestamp.Timestamp) bool { return tt.over },
+			}
+
+			mgoDal.On("FindOne", "election", mock.Anything, mock.Anything).Return(tt.electRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*pb.Election) = pb.Election{Id: 1, Weighted: tt.weighted}
+			}).Once()
+			mgoDal.On("Upsert", "weight", mock.Anything, mock.Anything).Return(tt.upsertRet).Run(func(args mock.Arguments) {
+				stored = append(stored, args.Get(2).(*pb.VoterWeight))
+			})
+
+			req, err := http.NewRequest("PUT", "localhost:9223/election/1/weights", strings.NewReader(tt.body))
+			assert.Nil(t, err, "could not create request")
+			rec := httptest.NewRecorder()
+			req = mux.SetURLVars(req, map[string]string{"id": tt.ID})
+
+			s.weights(rec, req)
+			res := rec.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.statusCode, res.StatusCode, "Did not get the same response code")
+			assert.Len(t, stored, tt.stored)
+			if tt.statusCode == http.StatusCreated {
+				assert.Equal(t, &pb.VoterWeight{ElectionId: 1, Voter: "v1", Weight: 100}, stored[0])
+			}
+		})
+	}
+}
+
+func Test_server_publicKey(t *testing.T) {
+	log, _ := zap.NewProduction()
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	s := &server{signingKey: priv, logger: log}
+
+	req, err := http.NewRequest("GET", "localhost:9223"+publicKeyPath, nil)
+	assert.Nil(t, err, "could not create request")
+	rec := httptest.NewRecorder()
+	s.publicKey(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	key, err := sign.ParsePublicKey(rec.Body.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, pub, key)
+}
+
 func Test_server_initRoutes(t *testing.T) {
 	tests := []struct {
 		name string
@@ -206,6 +1254,88 @@ func Test_isOver(t *testing.T) {
 	}
 }
 
+func Test_fillDetails(t *testing.T) {
+	details := []*pb.Candidate{{Id: "c1", Name: "Jane Doe"}, {Id: "c2", Name: "John Roe"}}
+
+	tests := []struct {
+		name           string
+		election       *pb.Election
+		wantCandidates []string
+		wantDetails    []*pb.Candidate
+		wantMsg        string
+	}{
+		{"Ids from details", &pb.Election{Details: details}, []string{"c1", "c2"}, details, ""},
+		{"Details matching candidates", &pb.Election{Candidates: []string{"c1", "c2"}, Details: details}, []string{"c1", "c2"}, details, ""},
+		{"Details from plain candidates", &pb.Election{Candidates: []string{"Jane"}}, []string{"Jane"}, []*pb.Candidate{{Id: "Jane", Name: "Jane"}}, ""},
+		{"Details out of order", &pb.Election{Candidates: []string{"c2", "c1"}, Details: details}, []string{"c2", "c1"}, details, errBadDetails},
+		{"Details without name", &pb.Election{Details: []*pb.Candidate{{Id: "c1"}}}, nil, []*pb.Candidate{{Id: "c1"}}, errBadDetails},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantMsg, fillDetails(tt.election))
+			assert.Equal(t, tt.wantCandidates, tt.election.GetCandidates())
+			assert.Equal(t, tt.wantDetails, tt.election.GetDetails())
+		})
+	}
+}
+
+func Test_checkLabels(t *testing.T) {
+	tests := []struct {
+		name       string
+		election   *pb.Election
+		wantLocale string
+		wantLabels []string
+		wantMsg    string
+	}{
+		{"No labels", &pb.Election{Candidates: []string{"c1"}}, "", nil, ""},
+		{"Canonical tags", &pb.Election{Candidates: []string{"c1"}, Locale: "EN-us", Labels: map[string]*pb.Labels{"pt-br": {Candidates: map[string]string{"c1": "Joana"}}}}, "en-US", []string{"pt-BR"}, ""},
+		{"Contest questions", &pb.Election{Contests: []*pb.Contest{{Id: "q1"}}, Labels: map[string]*pb.Labels{"fr": {Questions: map[string]string{"q1": "Approuver ?"}}}}, "", []string{"fr"}, ""},
+		{"Invalid locale", &pb.Election{Candidates: []string{"c1"}, Locale: "not a tag"}, "not a tag", nil, errBadLabels},
+		{"Invalid label tag", &pb.Election{Candidates: []string{"c1"}, Labels: map[string]*pb.Labels{"not a tag": {}}}, "", []string{"not a tag"}, errBadLabels},
+		{"Unknown candidate", &pb.Election{Candidates: []string{"c1"}, Labels: map[string]*pb.Labels{"fr": {Candidates: map[string]string{"c2": "Jean"}}}}, "", []string{"fr"}, errBadLabels},
+		{"Unknown contest", &pb.Election{Contests: []*pb.Contest{{Id: "q1"}}, Labels: map[string]*pb.Labels{"fr": {Questions: map[string]string{"q2": "?"}}}}, "", []string{"fr"}, errBadLabels},
+		{"Contest options", &pb.Election{Contests: []*pb.Contest{{Id: "q1", Candidates: []string{"yes", "no"}}}, Labels: map[string]*pb.Labels{"fr": {Options: map[string]*pb.OptionLabels{"q1": {Names: map[string]string{"yes": "Oui", "no": "Non"}}}}}}, "", []string{"fr"}, ""},
+		{"Options of unknown contest", &pb.Election{Contests: []*pb.Contest{{Id: "q1", Candidates: []string{"yes", "no"}}}, Labels: map[string]*pb.Labels{"fr": {Options: map[string]*pb.OptionLabels{"q2": {Names: map[string]string{"yes": "Oui"}}}}}}, "", []string{"fr"}, errBadLabels},
+		{"Unknown option", &pb.Election{Contests: []*pb.Contest{{Id: "q1", Candidates: []string{"yes", "no"}}}, Labels: map[string]*pb.Labels{"fr": {Options: map[string]*pb.OptionLabels{"q1": {Names: map[string]string{"maybe": "Peut-être"}}}}}}, "", []string{"fr"}, errBadLabels},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantMsg, checkLabels(tt.election))
+			assert.Equal(t, tt.wantLocale, tt.election.GetLocale())
+			var labels []string
+			for l := range tt.election.GetLabels() {
+				labels = append(labels, l)
+			}
+			assert.Equal(t, tt.wantLabels, labels)
+		})
+	}
+}
+
+func Test_server_locale(t *testing.T) {
+	s := &server{Locale: "en"}
+	labels := map[string]*pb.Labels{"pt-BR": {}, "fr": {}, "en": {}}
+
+	tests := []struct {
+		name     string
+		election *pb.Election
+		accept   string
+		want     string
+	}{
+		{"No header", &pb.Election{Labels: labels}, "", "en"},
+		{"Exact match", &pb.Election{Labels: labels}, "pt-BR", "pt-BR"},
+		{"Preferred match", &pb.Election{Labels: labels}, "de, fr;q=0.8, en;q=0.5", "fr"},
+		{"Close match", &pb.Election{Labels: labels}, "pt-PT", "pt-BR"},
+		{"No match", &pb.Election{Labels: labels}, "ja", "en"},
+		{"Election locale", &pb.Election{Locale: "es", Labels: labels}, "ja", "es"},
+		{"Invalid header", &pb.Election{Locale: "es"}, ";;;", "es"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.locale(tt.election, tt.accept))
+		})
+	}
+}
+
 func Test_containsCandidate(t *testing.T) {
 	type args struct {
 		candidate  string
@@ -229,3 +1359,110 @@ func Test_containsCandidate(t *testing.T) {
 		})
 	}
 }
+
+func Test_keep(t *testing.T) {
+	decided := &pb.Election{Id: 1, Committed: true, Parent: 4, RunoffId: 9, Seed: 5, BallotSeed: 6, Runoff: &pb.RunoffPolicy{Majority: 0.5, Duration: 60, Decided: true}}
+	tests := []struct {
+		name   string
+		stored *pb.Election
+		e      *pb.Election
+		want   *pb.Election
+	}{
+		{"New election", &pb.Election{}, &pb.Election{Id: 1, Committed: true, Parent: 2, RunoffId: 3, Seed: 7, BallotSeed: 8, Runoff: &pb.RunoffPolicy{Majority: 0.5, Decided: true}},
+			&pb.Election{Id: 1, Runoff: &pb.RunoffPolicy{Majority: 0.5}}},
+		{"Stored values kept", decided, &pb.Election{Id: 1, Seed: 7, Runoff: &pb.RunoffPolicy{Majority: 0.6, Duration: 60}},
+			&pb.Election{Id: 1, Committed: true, Parent: 4, RunoffId: 9, Seed: 5, BallotSeed: 6, Runoff: &pb.RunoffPolicy{Majority: 0.6, Duration: 60, Decided: true}}},
+		{"Withdrawals kept without withdrawn", &pb.Election{Withdrawn: []string{"b"}, Contests: []*pb.Contest{{Id: "q1", Withdrawn: []string{"no"}}}},
+			&pb.Election{Id: 1, Contests: []*pb.Contest{{Id: "q1"}, {Id: "q2"}}},
+			&pb.Election{Id: 1, Withdrawn: []string{"b"}, Contests: []*pb.Contest{{Id: "q1", Withdrawn: []string{"no"}}, {Id: "q2"}}}},
+		{"Withdrawals replaced", &pb.Election{Withdrawn: []string{"b"}}, &pb.Election{Id: 1, Withdrawn: []string{}},
+			&pb.Election{Id: 1, Withdrawn: []string{}}},
+		{"Decided runoff kept without policy", decided, &pb.Election{Id: 1},
+			&pb.Election{Id: 1, Committed: true, Parent: 4, RunoffId: 9, Seed: 5, BallotSeed: 6, Runoff: &pb.RunoffPolicy{Decided: true}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keep(tt.stored, tt.e)
+			assert.Equal(t, tt.want, tt.e)
+		})
+	}
+}
+
+func Test_server_shutdown(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+
+	tests := []struct {
+		name    string
+		request time.Duration
+		runoff  time.Duration
+		wantErr error
+	}{
+		{"Idle", 0, 0, nil},
+		{"Drains requests in flight", 20 * time.Millisecond, 0, nil},
+		{"Waits for the runoff check", 0, 20 * time.Millisecond, nil},
+		{"Request past the timeout", time.Second, 0, context.DeadlineExceeded},
+		{"Runoff check past the timeout", 0, time.Second, context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			mgoDal.Calls = nil
+			mgoDal.On("Close").Return()
+			s := &server{ShutdownTimeout: 100 * time.Millisecond, mgoDal: mgoDal}
+
+			started := make(chan struct{})
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(started)
+				time.Sleep(tt.request)
+			}))
+			defer ts.Close()
+			go http.Get(ts.URL)
+			<-started
+
+			var runoffs sync.WaitGroup
+			runoffs.Add(1)
+			go func() {
+				defer runoffs.Done()
+				time.Sleep(tt.runoff)
+			}()
+
+			assert.Equal(t, tt.wantErr, s.shutdown(ts.Config, &runoffs))
+			mgoDal.AssertCalled(t, "Close")
+		})
+	}
+}
+
+func Test_server_ready(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	log, _ := zap.NewProduction()
+
+	tests := []struct {
+		name       string
+		pingRet    error
+		statusCode int
+		want       []health.Dependency
+	}{
+		{"Ready", nil, http.StatusOK, []health.Dependency{{Name: "mongo", Status: "ok"}}},
+		{"Mongo down", errors.New("test error"), http.StatusServiceUnavailable, []health.Dependency{{Name: "mongo", Status: "down", Error: "test error"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			mgoDal.On("Ping").Return(tt.pingRet)
+			s := &server{mgoDal: mgoDal, logger: log}
+
+			req, err := http.NewRequest("GET", "http://localhost:9223/readyz", nil)
+			assert.Nil(t, err, "could not create request")
+			rec := httptest.NewRecorder()
+
+			s.initRoutes().ServeHTTP(rec, req)
+			res := rec.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.statusCode, res.StatusCode, "Did not get the same response code")
+			var report health.Report
+			assert.Nil(t, json.NewDecoder(res.Body).Decode(&report))
+			assert.Equal(t, tt.want, report.Dependencies)
+		})
+	}
+}
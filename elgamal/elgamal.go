@@ -0,0 +1,167 @@
+package elgamal
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/ednesic/vote-test/pb"
+)
+
+// 2048-bit MODP group from RFC 3526. p is a safe prime and g = 2 generates
+// the subgroup of prime order q = (p-1)/2.
+const modp2048 = "FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD1" +
+	"29024E088A67CC74020BBEA63B139B22514A08798E3404DD" +
+	"EF9519B3CD3A431B302B0A6DF25F14374FE1356D6D51C245" +
+	"E485B576625E7EC6F44C42E9A637ED6B0BFF5CB6F406B7ED" +
+	"EE386BFB5A899FA5AE9F24117C4B1FE649286651ECE45B3D" +
+	"C2007CB8A163BF0598DA48361C55D39A69163FA8FD24CF5F" +
+	"83655D23DCA3AD961C62F356208552BB9ED529077096966D" +
+	"670C354E4ABC9804F1746C08CA18217C32905E462E36CE3B" +
+	"E39E772C180E86039B2783A2EC07A28FB5C55DF06F4C52C9" +
+	"DE2BCBF6955817183995497CEA956AE515D2261898FA0510" +
+	"15728E5A8AACAA68FFFFFFFFFFFFFFFF"
+
+var (
+	p, _ = new(big.Int).SetString(modp2048, 16)
+	q    = new(big.Int).Rsh(p, 1)
+	g    = big.NewInt(2)
+	one  = big.NewInt(1)
+
+	// ErrInvalidCiphertext is returned for ciphertexts outside of the group
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+	// ErrInvalidKey is returned for keys outside of the group
+	ErrInvalidKey = errors.New("invalid key")
+	// ErrOutOfRange is returned when a plaintext is above the decryption bound
+	ErrOutOfRange = errors.New("plaintext out of range")
+	// ErrLengthMismatch is returned when adding vectors of different lengths
+	ErrLengthMismatch = errors.New("ciphertext vectors length mismatch")
+	// ErrInvalidProof is returned when a ciphertext proof does not verify
+	ErrInvalidProof = errors.New("invalid ciphertext proof")
+)
+
+// minSecret is the shortest secret keys are derived from
+const minSecret = 32
+
+// PrivateKey is an ElGamal private exponent x, its public key is g^x
+type PrivateKey struct {
+	x *big.Int
+}
+
+// DeriveKey derives the private key of an election from a secret kept outside
+// the database, so the key never has to be stored
+func DeriveKey(secret []byte, id int32) (*PrivateKey, error) {
+	if len(secret) < minSecret {
+		return nil, ErrInvalidKey
+	}
+	// expanding well past the size of q keeps the reduction close to uniform
+	var seed []byte
+	for i := byte(0); len(seed) < 2*len(q.Bytes()); i++ {
+		mac := hmac.New(sha512.New, secret)
+		binary.Write(mac, binary.BigEndian, id)
+		mac.Write([]byte{i})
+		seed = mac.Sum(seed)
+	}
+	x := new(big.Int).SetBytes(seed)
+	x.Mod(x, new(big.Int).Sub(q, one))
+	return &PrivateKey{x: x.Add(x, one)}, nil
+}
+
+// Public returns the encoded public key g^x
+func (k *PrivateKey) Public() []byte {
+	return new(big.Int).Exp(g, k.x, p).Bytes()
+}
+
+// Encrypt encrypts the small integer m as (g^r, g^m * h^r) under public key h
+func Encrypt(random io.Reader, pub []byte, m int64) (*pb.Ciphertext, error) {
+	h := new(big.Int).SetBytes(pub)
+	if !inGroup(h) {
+		return nil, ErrInvalidKey
+	}
+	r, err := randomExponent(random)
+	if err != nil {
+		return nil, err
+	}
+	return encrypt(h, m, r), nil
+}
+
+func encrypt(h *big.Int, m int64, r *big.Int) *pb.Ciphertext {
+	a := new(big.Int).Exp(g, r, p)
+	b := new(big.Int).Exp(h, r, p)
+	b.Mul(b, new(big.Int).Exp(g, big.NewInt(m), p))
+	b.Mod(b, p)
+	return &pb.Ciphertext{A: a.Bytes(), B: b.Bytes()}
+}
+
+// Add returns the ciphertext of the sum of the plaintexts of x and y
+func Add(x, y *pb.Ciphertext) (*pb.Ciphertext, error) {
+	if !Valid(x) || !Valid(y) {
+		return nil, ErrInvalidCiphertext
+	}
+	a := new(big.Int).Mul(new(big.Int).SetBytes(x.GetA()), new(big.Int).SetBytes(y.GetA()))
+	b := new(big.Int).Mul(new(big.Int).SetBytes(x.GetB()), new(big.Int).SetBytes(y.GetB()))
+	return &pb.Ciphertext{A: a.Mod(a, p).Bytes(), B: b.Mod(b, p).Bytes()}, nil
+}
+
+// AddVectors adds two choice vectors element by element
+func AddVectors(x, y []*pb.Ciphertext) ([]*pb.Ciphertext, error) {
+	if len(x) != len(y) {
+		return nil, ErrLengthMismatch
+	}
+	sums := make([]*pb.Ciphertext, len(x))
+	for i := range x {
+		sum, err := Add(x[i], y[i])
+		if err != nil {
+			return nil, err
+		}
+		sums[i] = sum
+	}
+	return sums, nil
+}
+
+// Decrypt recovers a plaintext between 0 and max by searching g^m
+func Decrypt(key *PrivateKey, c *pb.Ciphertext, max int64) (int64, error) {
+	if !Valid(c) {
+		return 0, ErrInvalidCiphertext
+	}
+	s := new(big.Int).Exp(new(big.Int).SetBytes(c.GetA()), key.x, p)
+	gm := new(big.Int).Mul(new(big.Int).SetBytes(c.GetB()), s.ModInverse(s, p))
+	gm.Mod(gm, p)
+
+	acc := big.NewInt(1)
+	for m := int64(0); m <= max; m++ {
+		if acc.Cmp(gm) == 0 {
+			return m, nil
+		}
+		acc.Mul(acc, g).Mod(acc, p)
+	}
+	return 0, ErrOutOfRange
+}
+
+// Valid reports whether both elements of c belong to the prime order subgroup
+func Valid(c *pb.Ciphertext) bool {
+	return c != nil && inGroup(new(big.Int).SetBytes(c.GetA())) && inGroup(new(big.Int).SetBytes(c.GetB()))
+}
+
+func inGroup(x *big.Int) bool {
+	if x.Cmp(one) < 0 || x.Cmp(p) >= 0 {
+		return false
+	}
+	return new(big.Int).Exp(x, q, p).Cmp(one) == 0
+}
+
+func randomExponent(random io.Reader) (*big.Int, error) {
+	for {
+		x, err := rand.Int(random, q)
+		if err != nil {
+			return nil, err
+		}
+		if x.Sign() > 0 {
+			return x, nil
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package elgamal
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Group(t *testing.T) {
+	assert.True(t, p.ProbablyPrime(20))
+	assert.True(t, q.ProbablyPrime(20))
+	assert.True(t, inGroup(g))
+}
+
+// newKey derives a key from a random secret
+func newKey() *PrivateKey {
+	secret := make([]byte, minSecret)
+	rand.Read(secret)
+	key, _ := DeriveKey(secret, 1)
+	return key
+}
+
+func Test_Decrypt(t *testing.T) {
+	key := newKey()
+
+	tests := []struct {
+		name    string
+		m       int64
+		max     int64
+		wantErr error
+	}{
+		{"Zero", 0, 1, nil},
+		{"One", 1, 1, nil},
+		{"Bound", 10, 10, nil},
+		{"Above bound", 11, 10, ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Encrypt(rand.Reader, key.Public(), tt.m)
+			assert.Nil(t, err)
+			m, err := Decrypt(key, c, tt.max)
+			assert.Equal(t, tt.wantErr, err)
+			if err == nil {
+				assert.Equal(t, tt.m, m)
+			}
+		})
+	}
+}
+
+func Test_AddVectors(t *testing.T) {
+	key := newKey()
+	ballots := [][]int64{{1, 0, 0}, {0, 1, 0}, {1, 0, 0}, {0, 0, 1}, {1, 0, 0}}
+
+	var sums []*pb.Ciphertext
+	for _, ballot := range ballots {
+		var vector []*pb.Ciphertext
+		for _, m := range ballot {
+			c, err := Encrypt(rand.Reader, key.Public(), m)
+			assert.Nil(t, err)
+			vector = append(vector, c)
+		}
+		if sums == nil {
+			sums = vector
+			continue
+		}
+		var err error
+		sums, err = AddVectors(sums, vector)
+		assert.Nil(t, err)
+	}
+
+	var got []int64
+	for _, c := range sums {
+		m, err := Decrypt(key, c, int64(len(ballots)))
+		assert.Nil(t, err)
+		got = append(got, m)
+	}
+	assert.Equal(t, []int64{3, 1, 1}, got)
+
+	_, err := AddVectors(sums, sums[:1])
+	assert.Equal(t, ErrLengthMismatch, err)
+}
+
+func Test_Valid(t *testing.T) {
+	key := newKey()
+	c, _ := Encrypt(rand.Reader, key.Public(), 1)
+	nonResidue := new(big.Int).Sub(p, one).Bytes()
+
+	tests := []struct {
+		name string
+		c    *pb.Ciphertext
+		want bool
+	}{
+		{"Valid", c, true},
+		{"Nil", nil, false},
+		{"Zero element", &pb.Ciphertext{A: []byte{0}, B: c.GetB()}, false},
+		{"Element above p", &pb.Ciphertext{A: c.GetA(), B: append(p.Bytes(), 1)}, false},
+		{"Element outside subgroup", &pb.Ciphertext{A: nonResidue, B: c.GetB()}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Valid(tt.c))
+		})
+	}
+}
+
+func Test_Encrypt(t *testing.T) {
+	_, err := Encrypt(rand.Reader, []byte{0}, 1)
+	assert.Equal(t, ErrInvalidKey, err)
+}
+
+func Test_DeriveKey(t *testing.T) {
+	secret := make([]byte, minSecret)
+	rand.Read(secret)
+
+	a, err := DeriveKey(secret, 1)
+	assert.Nil(t, err)
+	b, err := DeriveKey(secret, 1)
+	assert.Nil(t, err)
+	c, err := DeriveKey(secret, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, a.Public(), b.Public())
+	assert.NotEqual(t, a.Public(), c.Public())
+
+	_, err = DeriveKey(secret[:minSecret-1], 1)
+	assert.Equal(t, ErrInvalidKey, err)
+}
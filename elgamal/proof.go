@@ -0,0 +1,199 @@
+package elgamal
+
+import (
+	"crypto/sha256"
+	"io"
+	"math/big"
+
+	"github.com/ednesic/vote-test/pb"
+)
+
+// EncryptChoices encrypts a vector of 0 or 1 choices under public key h with
+// a proof for every choice and a proof that the choices sum to their total
+func EncryptChoices(random io.Reader, pub []byte, choices []int64) ([]*pb.Ciphertext, []*pb.ChoiceProof, *pb.SumProof, error) {
+	h := new(big.Int).SetBytes(pub)
+	if !inGroup(h) {
+		return nil, nil, nil, ErrInvalidKey
+	}
+
+	var (
+		cs     = make([]*pb.Ciphertext, len(choices))
+		proofs = make([]*pb.ChoiceProof, len(choices))
+		r      = new(big.Int)
+		total  int64
+	)
+	for i, m := range choices {
+		if m != 0 && m != 1 {
+			return nil, nil, nil, ErrOutOfRange
+		}
+		ri, err := randomExponent(random)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cs[i] = encrypt(h, m, ri)
+		proofs[i], err = proveChoice(random, h, cs[i], m, ri)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		r.Add(r, ri).Mod(r, q)
+		total += m
+	}
+
+	sum, err := proveSum(random, h, cs, total, r)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return cs, proofs, sum, nil
+}
+
+// VerifyChoice checks that c encrypts 0 or 1 under public key h
+func VerifyChoice(pub []byte, c *pb.Ciphertext, proof *pb.ChoiceProof) bool {
+	h := new(big.Int).SetBytes(pub)
+	if !inGroup(h) || !Valid(c) || proof == nil {
+		return false
+	}
+	a, b := new(big.Int).SetBytes(c.GetA()), new(big.Int).SetBytes(c.GetB())
+	a0, b0 := new(big.Int).SetBytes(proof.GetA0()), new(big.Int).SetBytes(proof.GetB0())
+	a1, b1 := new(big.Int).SetBytes(proof.GetA1()), new(big.Int).SetBytes(proof.GetB1())
+	c0, c1 := new(big.Int).SetBytes(proof.GetC0()), new(big.Int).SetBytes(proof.GetC1())
+	f0, f1 := new(big.Int).SetBytes(proof.GetF0()), new(big.Int).SetBytes(proof.GetF1())
+	if !inGroup(a0) || !inGroup(b0) || !inGroup(a1) || !inGroup(b1) || !exponent(c0) || !exponent(c1) || !exponent(f0) || !exponent(f1) {
+		return false
+	}
+
+	challenge := new(big.Int).Add(c0, c1)
+	if challenge.Mod(challenge, q).Cmp(hash(h, a, b, a0, b0, a1, b1)) != 0 {
+		return false
+	}
+	return verifyEqual(h, a, b, 0, a0, b0, c0, f0) && verifyEqual(h, a, b, 1, a1, b1, c1, f1)
+}
+
+// VerifySum checks that the sum of the ciphertexts encrypts total under
+// public key h
+func VerifySum(pub []byte, cs []*pb.Ciphertext, total int64, proof *pb.SumProof) bool {
+	h := new(big.Int).SetBytes(pub)
+	if !inGroup(h) || proof == nil {
+		return false
+	}
+	a, b, ok := product(cs)
+	if !ok {
+		return false
+	}
+	ta, tb, f := new(big.Int).SetBytes(proof.GetA()), new(big.Int).SetBytes(proof.GetB()), new(big.Int).SetBytes(proof.GetF())
+	if !inGroup(ta) || !inGroup(tb) || !exponent(f) {
+		return false
+	}
+	return verifyEqual(h, a, b, total, ta, tb, hash(h, a, b, big.NewInt(total), ta, tb), f)
+}
+
+// proveChoice proves that c = (g^r, g^m h^r) encrypts 0 or 1. The branch of
+// the other plaintext is simulated with a chosen challenge and response.
+func proveChoice(random io.Reader, h *big.Int, c *pb.Ciphertext, m int64, r *big.Int) (*pb.ChoiceProof, error) {
+	a, b := new(big.Int).SetBytes(c.GetA()), new(big.Int).SetBytes(c.GetB())
+	var ta, tb, cs, fs [2]*big.Int
+
+	other := 1 - m
+	var err error
+	cs[other], err = randomExponent(random)
+	if err != nil {
+		return nil, err
+	}
+	fs[other], err = randomExponent(random)
+	if err != nil {
+		return nil, err
+	}
+	ta[other], tb[other] = simulate(h, a, b, other, cs[other], fs[other])
+
+	w, err := randomExponent(random)
+	if err != nil {
+		return nil, err
+	}
+	ta[m], tb[m] = new(big.Int).Exp(g, w, p), new(big.Int).Exp(h, w, p)
+
+	challenge := hash(h, a, b, ta[0], tb[0], ta[1], tb[1])
+	cs[m] = challenge.Sub(challenge, cs[other]).Mod(challenge, q)
+	fs[m] = respond(w, cs[m], r)
+
+	return &pb.ChoiceProof{
+		A0: ta[0].Bytes(), B0: tb[0].Bytes(), C0: cs[0].Bytes(), F0: fs[0].Bytes(),
+		A1: ta[1].Bytes(), B1: tb[1].Bytes(), C1: cs[1].Bytes(), F1: fs[1].Bytes(),
+	}, nil
+}
+
+// proveSum proves that the product of cs, encrypted with the summed
+// randomness r, encrypts total
+func proveSum(random io.Reader, h *big.Int, cs []*pb.Ciphertext, total int64, r *big.Int) (*pb.SumProof, error) {
+	a, b, _ := product(cs)
+	w, err := randomExponent(random)
+	if err != nil {
+		return nil, err
+	}
+	ta, tb := new(big.Int).Exp(g, w, p), new(big.Int).Exp(h, w, p)
+	f := respond(w, hash(h, a, b, big.NewInt(total), ta, tb), r)
+	return &pb.SumProof{A: ta.Bytes(), B: tb.Bytes(), F: f.Bytes()}, nil
+}
+
+// verifyEqual checks that log_g(a) = log_h(b / g^m) through the commitments
+// (ta, tb), challenge c and response f: g^f = ta a^c and h^f = tb (b/g^m)^c
+func verifyEqual(h, a, b *big.Int, m int64, ta, tb, c, f *big.Int) bool {
+	left := new(big.Int).Exp(g, f, p)
+	right := new(big.Int).Exp(a, c, p)
+	if left.Cmp(right.Mul(right, ta).Mod(right, p)) != 0 {
+		return false
+	}
+	left.Exp(h, f, p)
+	right.Exp(unshift(b, m), c, p)
+	return left.Cmp(right.Mul(right, tb).Mod(right, p)) == 0
+}
+
+// simulate returns the commitments a proof with challenge c and response f
+// verifies against for plaintext m
+func simulate(h, a, b *big.Int, m int64, c, f *big.Int) (*big.Int, *big.Int) {
+	neg := new(big.Int).Sub(q, c)
+	ta := new(big.Int).Exp(a, neg, p)
+	ta.Mul(ta, new(big.Int).Exp(g, f, p)).Mod(ta, p)
+	tb := new(big.Int).Exp(unshift(b, m), neg, p)
+	tb.Mul(tb, new(big.Int).Exp(h, f, p)).Mod(tb, p)
+	return ta, tb
+}
+
+// respond returns w + c r mod q
+func respond(w, c, r *big.Int) *big.Int {
+	f := new(big.Int).Mul(c, r)
+	f.Add(f, w)
+	return f.Mod(f, q)
+}
+
+// unshift returns b / g^m
+func unshift(b *big.Int, m int64) *big.Int {
+	gm := new(big.Int).Exp(g, big.NewInt(m), p)
+	return gm.ModInverse(gm, p).Mul(gm, b).Mod(gm, p)
+}
+
+// product multiplies the ciphertexts element by element, which adds their
+// plaintexts
+func product(cs []*pb.Ciphertext) (*big.Int, *big.Int, bool) {
+	a, b := big.NewInt(1), big.NewInt(1)
+	for _, c := range cs {
+		if !Valid(c) {
+			return nil, nil, false
+		}
+		a.Mul(a, new(big.Int).SetBytes(c.GetA())).Mod(a, p)
+		b.Mul(b, new(big.Int).SetBytes(c.GetB())).Mod(b, p)
+	}
+	return a, b, true
+}
+
+// hash derives the Fiat-Shamir challenge from the statement and commitments
+func hash(xs ...*big.Int) *big.Int {
+	d := sha256.New()
+	buf := make([]byte, len(p.Bytes()))
+	for _, x := range xs {
+		d.Write(x.FillBytes(buf))
+	}
+	return new(big.Int).SetBytes(d.Sum(nil))
+}
+
+func exponent(x *big.Int) bool {
+	return x.Cmp(q) < 0
+}
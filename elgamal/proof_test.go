@@ -0,0 +1,77 @@
+package elgamal
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_VerifyChoice(t *testing.T) {
+	key := newKey()
+	other := newKey()
+	cs, proofs, _, err := EncryptChoices(rand.Reader, key.Public(), []int64{0, 1})
+	assert.Nil(t, err)
+	two, _ := Encrypt(rand.Reader, key.Public(), 2)
+	tampered := *proofs[1]
+	tampered.F1 = new(big.Int).Add(new(big.Int).SetBytes(tampered.F1), one).Bytes()
+
+	tests := []struct {
+		name  string
+		pub   []byte
+		c     *pb.Ciphertext
+		proof *pb.ChoiceProof
+		want  bool
+	}{
+		{"Zero", key.Public(), cs[0], proofs[0], true},
+		{"One", key.Public(), cs[1], proofs[1], true},
+		{"Proof of another ciphertext", key.Public(), cs[0], proofs[1], false},
+		{"Two with a proof for one", key.Public(), two, proofs[1], false},
+		{"Tampered response", key.Public(), cs[1], &tampered, false},
+		{"Other key", other.Public(), cs[0], proofs[0], false},
+		{"No proof", key.Public(), cs[0], nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, VerifyChoice(tt.pub, tt.c, tt.proof))
+		})
+	}
+}
+
+func Test_VerifySum(t *testing.T) {
+	key := newKey()
+	single, _, sum, err := EncryptChoices(rand.Reader, key.Public(), []int64{0, 1, 0})
+	assert.Nil(t, err)
+	double, _, doubleSum, err := EncryptChoices(rand.Reader, key.Public(), []int64{1, 1, 0})
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name  string
+		cs    []*pb.Ciphertext
+		total int64
+		proof *pb.SumProof
+		want  bool
+	}{
+		{"Sums to one", single, 1, sum, true},
+		{"Sums to two", double, 2, doubleSum, true},
+		{"Two claimed as one", double, 1, doubleSum, false},
+		{"Proof of another vector", double, 1, sum, false},
+		{"Missing ciphertext", single[:2], 1, sum, false},
+		{"No proof", single, 1, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, VerifySum(key.Public(), tt.cs, tt.total, tt.proof))
+		})
+	}
+}
+
+func Test_EncryptChoices(t *testing.T) {
+	key := newKey()
+	_, _, _, err := EncryptChoices(rand.Reader, key.Public(), []int64{0, 2})
+	assert.Equal(t, ErrOutOfRange, err)
+	_, _, _, err = EncryptChoices(rand.Reader, []byte{0}, []int64{0, 1})
+	assert.Equal(t, ErrInvalidKey, err)
+}
@@ -0,0 +1,126 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/nats-io/go-nats-streaming"
+)
+
+const (
+	statusOK   = "ok"
+	statusDown = "down"
+
+	// LivePath answers as long as the process serves requests
+	LivePath = "/healthz"
+	// ReadyPath answers whether the dependencies of the service are usable
+	ReadyPath = "/readyz"
+)
+
+var (
+	// ErrTimeout is reported for checks that do not finish in time
+	ErrTimeout = errors.New("check timed out")
+	// ErrNatsDown is reported when the nats connection is not connected
+	ErrNatsDown = errors.New("nats not connected")
+)
+
+// Check tells whether a dependency is usable
+type Check func() error
+
+// Dependency is the status of one dependency in a readiness report
+type Dependency struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the answer of the readiness endpoint
+type Report struct {
+	Status       string       `json:"status"`
+	Dependencies []Dependency `json:"dependencies,omitempty"`
+}
+
+// Live answers ok whenever the service is able to serve a request
+func Live(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	j, _ := json.Marshal(Report{Status: statusOK})
+	w.Write(j)
+}
+
+// Ready returns a handler running every check at once, it answers with the
+// status of each dependency and 503 when any of them is down or does not
+// answer within timeout
+func Ready(checks map[string]Check, timeout time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := Run(checks, timeout)
+		stsCode := http.StatusOK
+		if report.Status != statusOK {
+			stsCode = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(stsCode)
+		j, _ := json.Marshal(report)
+		w.Write(j)
+	}
+}
+
+// Run runs the checks at once and reports the dependencies by name
+func Run(checks map[string]Check, timeout time.Duration) Report {
+	type result struct {
+		name string
+		err  error
+	}
+	results := make(chan result, len(checks))
+	for name, check := range checks {
+		go func(name string, check Check) {
+			results <- result{name, check()}
+		}(name, check)
+	}
+
+	report := Report{Status: statusOK}
+	errs := make(map[string]error, len(checks))
+	deadline := time.After(timeout)
+	for len(errs) < len(checks) {
+		select {
+		case r := <-results:
+			errs[r.name] = r.err
+		case <-deadline:
+			for name := range checks {
+				if _, ok := errs[name]; !ok {
+					errs[name] = ErrTimeout
+				}
+			}
+		}
+	}
+
+	for name, err := range errs {
+		d := Dependency{Name: name, Status: statusOK}
+		if err != nil {
+			d.Status = statusDown
+			d.Error = err.Error()
+			report.Status = statusDown
+		}
+		report.Dependencies = append(report.Dependencies, d)
+	}
+	sort.Slice(report.Dependencies, func(i, j int) bool {
+		return report.Dependencies[i].Name < report.Dependencies[j].Name
+	})
+	return report
+}
+
+// Nats tells whether the nats connection under a streaming connection is
+// connected
+func Nats(conn stan.Conn) error {
+	if conn == nil {
+		return ErrNatsDown
+	}
+	nc := conn.NatsConn()
+	if nc == nil || !nc.IsConnected() {
+		return ErrNatsDown
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ednesic/vote-test/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ready(t *testing.T) {
+	up := func() error { return nil }
+	down := func() error { return errors.New("test error") }
+	hang := func() error {
+		time.Sleep(time.Second)
+		return nil
+	}
+
+	tests := []struct {
+		name       string
+		checks     map[string]Check
+		statusCode int
+		want       []Dependency
+	}{
+		{"No dependencies", nil, http.StatusOK, nil},
+		{"All up", map[string]Check{"nats": up, "mongo": up}, http.StatusOK,
+			[]Dependency{{Name: "mongo", Status: statusOK}, {Name: "nats", Status: statusOK}}},
+		{"One down", map[string]Check{"nats": up, "mongo": down}, http.StatusServiceUnavailable,
+			[]Dependency{{Name: "mongo", Status: statusDown, Error: "test error"}, {Name: "nats", Status: statusOK}}},
+		{"Check timed out", map[string]Check{"mongo": hang}, http.StatusServiceUnavailable,
+			[]Dependency{{Name: "mongo", Status: statusDown, Error: ErrTimeout.Error()}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", ReadyPath, nil)
+			assert.Nil(t, err, "could not create request")
+
+			Ready(tt.checks, 50*time.Millisecond)(rec, req)
+			res := rec.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.statusCode, res.StatusCode)
+			var report Report
+			assert.Nil(t, json.NewDecoder(res.Body).Decode(&report))
+			assert.Equal(t, tt.want, report.Dependencies)
+		})
+	}
+}
+
+func Test_live(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", LivePath, nil)
+	assert.Nil(t, err, "could not create request")
+
+	Live(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
+
+func Test_nats(t *testing.T) {
+	assert.Equal(t, ErrNatsDown, Nats(nil))
+	assert.Equal(t, ErrNatsDown, Nats(new(tests.StanConnMock)))
+}
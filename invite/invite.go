@@ -0,0 +1,34 @@
+package invite
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"io"
+	"strings"
+)
+
+const codeBytes = 10
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Generate returns n random one-time codes
+func Generate(n int) ([]string, error) {
+	codes := make([]string, n)
+	b := make([]byte, codeBytes)
+	for i := range codes {
+		if _, err := io.ReadFull(rand.Reader, b); err != nil {
+			return nil, err
+		}
+		codes[i] = encoding.EncodeToString(b)
+	}
+	return codes, nil
+}
+
+// Hash returns the stored form of a code. Codes are case insensitive and
+// surrounding spaces are ignored since they are typed in by voters.
+func Hash(code string) string {
+	h := sha256.Sum256([]byte(strings.ToUpper(strings.TrimSpace(code))))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,40 @@
+package invite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Generate(t *testing.T) {
+	codes, err := Generate(100)
+	assert.Nil(t, err)
+	assert.Len(t, codes, 100)
+
+	seen := make(map[string]bool)
+	for _, c := range codes {
+		assert.Len(t, c, 16)
+		assert.False(t, seen[c], "duplicated code %s", c)
+		seen[c] = true
+	}
+}
+
+func Test_Hash(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		same bool
+	}{
+		{"Same code", "ABCD", "ABCD", true},
+		{"Lower case", "ABCD", "abcd", true},
+		{"Surrounding spaces", "ABCD", " ABCD\n", true},
+		{"Other code", "ABCD", "ABCE", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.same, Hash(tt.a) == Hash(tt.b))
+		})
+	}
+	assert.NotContains(t, Hash("ABCD"), "ABCD")
+}
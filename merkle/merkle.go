@@ -0,0 +1,133 @@
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"sort"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	leafPrefix = 0x00
+	nodePrefix = 0x01
+)
+
+// ErrIndexOutOfRange is returned when a proof is requested for a missing leaf
+var ErrIndexOutOfRange = errors.New("leaf index out of range")
+
+// Step is one sibling hash on the path from a leaf to the root
+type Step struct {
+	Hash []byte
+	Left bool
+}
+
+// Tree is a binary hash tree where an odd node is promoted to the next level
+type Tree struct {
+	levels [][][]byte
+}
+
+// New builds a tree over the given leaves
+func New(leaves [][]byte) *Tree {
+	level := make([][]byte, len(leaves))
+	for i, l := range leaves {
+		level[i] = LeafHash(l)
+	}
+	t := &Tree{levels: [][][]byte{level}}
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, nodeHash(level[i], level[i+1]))
+		}
+		t.levels = append(t.levels, next)
+		level = next
+	}
+	return t
+}
+
+// Root returns the root hash, the hash of nothing for an empty tree
+func (t *Tree) Root() []byte {
+	top := t.levels[len(t.levels)-1]
+	if len(top) == 0 {
+		h := sha256.Sum256(nil)
+		return h[:]
+	}
+	return top[0]
+}
+
+// Proof returns the inclusion path of the leaf at index
+func (t *Tree) Proof(index int) ([]Step, error) {
+	if index < 0 || index >= len(t.levels[0]) {
+		return nil, ErrIndexOutOfRange
+	}
+	var path []Step
+	for _, level := range t.levels[:len(t.levels)-1] {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			path = append(path, Step{Hash: level[sibling], Left: sibling < index})
+		}
+		index /= 2
+	}
+	return path, nil
+}
+
+// Verify checks that leaf is included under root through path
+func Verify(leaf []byte, path []Step, root []byte) bool {
+	h := LeafHash(leaf)
+	for _, s := range path {
+		if s.Left {
+			h = nodeHash(s.Hash, h)
+		} else {
+			h = nodeHash(h, s.Hash)
+		}
+	}
+	return bytes.Equal(h, root)
+}
+
+// LeafHash hashes leaf data with a prefix distinct from inner nodes
+func LeafHash(data []byte) []byte {
+	h := sha256.Sum256(append([]byte{leafPrefix}, data...))
+	return h[:]
+}
+
+func nodeHash(left, right []byte) []byte {
+	buf := make([]byte, 0, 1+len(left)+len(right))
+	buf = append(buf, nodePrefix)
+	buf = append(buf, left...)
+	buf = append(buf, right...)
+	h := sha256.Sum256(buf)
+	return h[:]
+}
+
+// Ballots sorts the votes by receipt and builds the tree of their proto encoding,
+// so every service derives the same root from the same stored ballots
+func Ballots(votes []pb.Vote) (*Tree, error) {
+	sort.Slice(votes, func(i, j int) bool {
+		return votes[i].GetReceipt() < votes[j].GetReceipt()
+	})
+	leaves := make([][]byte, len(votes))
+	for i := range votes {
+		leaf, err := BallotLeaf(&votes[i])
+		if err != nil {
+			return nil, err
+		}
+		leaves[i] = leaf
+	}
+	return New(leaves), nil
+}
+
+// BallotLeaf returns the leaf data committed for a vote
+func BallotLeaf(v *pb.Vote) ([]byte, error) {
+	b := proto.NewBuffer(nil)
+	b.SetDeterministic(true)
+	if err := b.Marshal(v); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
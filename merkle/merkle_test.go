@@ -0,0 +1,86 @@
+package merkle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func leaves(n int) [][]byte {
+	l := make([][]byte, n)
+	for i := range l {
+		l[i] = []byte(fmt.Sprint("ballot", i))
+	}
+	return l
+}
+
+func Test_Proof(t *testing.T) {
+	tests := []struct {
+		name   string
+		leaves int
+	}{
+		{"Single leaf", 1},
+		{"Two leaves", 2},
+		{"Odd leaves", 5},
+		{"Power of two", 8},
+		{"Many leaves", 13},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := leaves(tt.leaves)
+			tree := New(l)
+			for i := range l {
+				path, err := tree.Proof(i)
+				assert.Nil(t, err)
+				assert.True(t, Verify(l[i], path, tree.Root()), "leaf %d not verified", i)
+				assert.False(t, Verify([]byte("forged"), path, tree.Root()), "forged leaf %d verified", i)
+			}
+		})
+	}
+}
+
+func Test_ProofOutOfRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		index int
+	}{
+		{"Negative index", -1},
+		{"Index after last leaf", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(leaves(3)).Proof(tt.index)
+			assert.Equal(t, ErrIndexOutOfRange, err)
+		})
+	}
+}
+
+func Test_Root(t *testing.T) {
+	assert.NotEmpty(t, New(nil).Root())
+	assert.Equal(t, LeafHash(leaves(1)[0]), New(leaves(1)).Root())
+	assert.NotEqual(t, New(leaves(3)).Root(), New(leaves(4)).Root())
+}
+
+func Test_Ballots(t *testing.T) {
+	votes := []pb.Vote{
+		{ElectionId: 1, Candidate: "a", Receipt: "c"},
+		{ElectionId: 1, Candidate: "b", Receipt: "a"},
+		{ElectionId: 1, Candidate: "a", Receipt: "b"},
+	}
+	shuffled := []pb.Vote{votes[2], votes[0], votes[1]}
+
+	tree, err := Ballots(votes)
+	assert.Nil(t, err)
+	other, err := Ballots(shuffled)
+	assert.Nil(t, err)
+	assert.Equal(t, tree.Root(), other.Root(), "order of stored ballots must not change the root")
+	assert.Equal(t, "a", votes[0].GetReceipt())
+
+	leaf, err := BallotLeaf(&votes[1])
+	assert.Nil(t, err)
+	path, err := tree.Proof(1)
+	assert.Nil(t, err)
+	assert.True(t, Verify(leaf, path, tree.Root()))
+}
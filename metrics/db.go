@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/ednesic/vote-test/db"
+)
+
+// DAL observes the latency of every operation of a data access layer
+func DAL(dal db.DataAccessLayer) db.DataAccessLayer {
+	return &observedDAL{dal}
+}
+
+type observedDAL struct {
+	dal db.DataAccessLayer
+}
+
+func observe(operation, collName string, start time.Time) {
+	mongoLatency.WithLabelValues(operation, collName).Observe(time.Since(start).Seconds())
+}
+
+func (o *observedDAL) Insert(collName string, doc interface{}) error {
+	defer observe("insert", collName, time.Now())
+	return o.dal.Insert(collName, doc)
+}
+
+func (o *observedDAL) InsertAll(collName string, docs []interface{}) error {
+	defer observe("insert_all", collName, time.Now())
+	return o.dal.InsertAll(collName, docs)
+}
+
+func (o *observedDAL) FindOne(collName string, query interface{}, doc interface{}) error {
+	defer observe("find_one", collName, time.Now())
+	return o.dal.FindOne(collName, query, doc)
+}
+
+func (o *observedDAL) FindAll(collName string, query interface{}, docs interface{}) error {
+	defer observe("find_all", collName, time.Now())
+	return o.dal.FindAll(collName, query, docs)
+}
+
+func (o *observedDAL) FindFirst(collName string, query interface{}, sort string, doc interface{}) error {
+	defer observe("find_first", collName, time.Now())
+	return o.dal.FindFirst(collName, query, sort, doc)
+}
+
+func (o *observedDAL) Update(collName string, selector interface{}, update interface{}) error {
+	defer observe("update", collName, time.Now())
+	return o.dal.Update(collName, selector, update)
+}
+
+func (o *observedDAL) Upsert(collName string, selector interface{}, update interface{}) error {
+	defer observe("upsert", collName, time.Now())
+	return o.dal.Upsert(collName, selector, update)
+}
+
+func (o *observedDAL) Remove(collName string, selector interface{}) error {
+	defer observe("remove", collName, time.Now())
+	return o.dal.Remove(collName, selector)
+}
+
+func (o *observedDAL) EnsureIndex(collName string, unique bool, fields ...string) error {
+	defer observe("ensure_index", collName, time.Now())
+	return o.dal.EnsureIndex(collName, unique, fields...)
+}
+
+func (o *observedDAL) Ping() error {
+	defer observe("ping", "", time.Now())
+	return o.dal.Ping()
+}
+
+func (o *observedDAL) Close() {
+	o.dal.Close()
+}
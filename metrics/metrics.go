@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/nats-io/go-nats-streaming"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace = "vote"
+
+	// Path serves the metrics of a service
+	Path = "/metrics"
+
+	unmatched = "unmatched"
+
+	routeLabel      = "route"
+	methodLabel     = "method"
+	statusLabel     = "status"
+	reasonLabel     = "reason"
+	operationLabel  = "operation"
+	collectionLabel = "collection"
+)
+
+var (
+	requests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route, method and status code.",
+	}, []string{routeLabel, methodLabel, statusLabel})
+
+	requestLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by route, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{routeLabel, methodLabel, statusLabel})
+
+	mongoLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "mongo_operation_duration_seconds",
+		Help:      "Latency of mongo operations by operation and collection.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{operationLabel, collectionLabel})
+
+	// VotesPublished counts the votes the vote service published
+	VotesPublished = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "votes_published_total",
+		Help:      "Votes published for processing.",
+	})
+
+	// VotesProcessed counts the votes the processor stored
+	VotesProcessed = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "votes_processed_total",
+		Help:      "Votes processed and stored.",
+	})
+
+	// VotesRejected counts the votes the processor did not store, by the step
+	// that rejected them
+	VotesRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "votes_rejected_total",
+		Help:      "Votes rejected by the processor by reason.",
+	}, []string{reasonLabel})
+
+	// ValidateLatency is the latency of ballot validation by the election
+	// service
+	ValidateLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "validate_vote_duration_seconds",
+		Help:      "Latency of ballot validation by the election service.",
+		Buckets:   prometheus.DefBuckets,
+	})
+)
+
+// Handler serves the metrics in the prometheus text format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Middleware counts the requests of a router and observes their latency by
+// route template, so path parameters do not make a series each
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := unmatched
+		if current := mux.CurrentRoute(r); current != nil {
+			if tpl, err := current.GetPathTemplate(); err == nil {
+				route = tpl
+			}
+		}
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(rec, r)
+
+		status := strconv.Itoa(rec.status)
+		requests.WithLabelValues(route, r.Method, status).Inc()
+		requestLatency.WithLabelValues(route, r.Method, status).Observe(time.Since(start).Seconds())
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Pending exposes the messages received by a subscription and not yet
+// handled
+func Pending(sub stan.Subscription) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "nats_pending_messages",
+		Help:      "Messages received by the nats subscription and not yet handled.",
+	}, func() float64 {
+		msgs, _, err := sub.Pending()
+		if err != nil {
+			return 0
+		}
+		return float64(msgs)
+	})
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ednesic/vote-test/tests"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_middleware(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/election/{id}", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not found election", http.StatusNotFound)
+	}).Methods(http.MethodGet)
+	router.HandleFunc("/election", func(w http.ResponseWriter, r *http.Request) {}).Methods(http.MethodPut)
+	router.Handle(Path, Handler()).Methods(http.MethodGet)
+	router.Use(Middleware)
+
+	for _, path := range []string{"/election/1", "/election/2"} {
+		req, err := http.NewRequest("GET", "http://localhost:9223"+path, nil)
+		assert.Nil(t, err, "could not create request")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	req, err := http.NewRequest("PUT", "http://localhost:9223/election", nil)
+	assert.Nil(t, err, "could not create request")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(requests.WithLabelValues("/election/{id}", "GET", "404")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(requests.WithLabelValues("/election", "PUT", "200")))
+
+	req, err = http.NewRequest("GET", "http://localhost:9223"+Path, nil)
+	assert.Nil(t, err, "could not create request")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.Contains(rec.Body.String(), `vote_http_request_duration_seconds_count{method="GET",route="/election/{id}",status="404"} 2`))
+}
+
+func Test_DAL(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	mgoDal.On("FindOne", "election", "query", "doc").Return(nil)
+	mgoDal.On("Ping").Return(nil)
+	mgoDal.On("Close").Return()
+
+	dal := DAL(mgoDal)
+	assert.Nil(t, dal.FindOne("election", "query", "doc"))
+	assert.Nil(t, dal.FindOne("election", "query", "doc"))
+	assert.Nil(t, dal.Ping())
+	dal.Close()
+
+	mgoDal.AssertNumberOfCalls(t, "FindOne", 2)
+	mgoDal.AssertCalled(t, "Close")
+	assert.Equal(t, 2, testutil.CollectAndCount(mongoLatency))
+	assert.True(t, strings.Contains(collect(t), `vote_mongo_operation_duration_seconds_count{collection="election",operation="find_one"} 2`))
+}
+
+func Test_pending(t *testing.T) {
+	Pending(&tests.SubscriptionMock{})
+	assert.True(t, strings.Contains(collect(t), "vote_nats_pending_messages 0"))
+}
+
+func collect(t *testing.T) string {
+	req, err := http.NewRequest("GET", Path, nil)
+	assert.Nil(t, err, "could not create request")
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, req)
+	return rec.Body.String()
+}
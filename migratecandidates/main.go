@@ -0,0 +1,192 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ednesic/vote-test/db"
+	"github.com/ednesic/vote-test/pb"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const usage = `usage: migratecandidates [flags]
+
+Gives elections stored with plain candidate names candidate details with
+stable ids and rewrites their stored ballots to choose candidates by id. Run
+it while the services are stopped. Elections with signed results or a ballot
+commitment keep their names as ids, so signatures and proofs over their
+ballots stay valid. Elections with contests are left as they are, their
+contests have no candidate details to migrate to.
+`
+
+type colls struct {
+	elections   string
+	votes       string
+	commitments string
+	results     string
+}
+
+func main() {
+	mgoURL := flag.String("mongo", "localhost:27017", "url of mongodb")
+	database := flag.String("db", "elections", "database of the services")
+	var c colls
+	flag.StringVar(&c.elections, "elections", "election", "election collection")
+	flag.StringVar(&c.votes, "votes", "vote", "vote collection")
+	flag.StringVar(&c.commitments, "commitments", "commitment", "commitment collection")
+	flag.StringVar(&c.results, "results", "results", "results collection")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dal, err := db.NewMongoDAL(*mgoURL, *database)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	migrated, err := migrateAll(dal, c)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("migrated %d elections\n", migrated)
+}
+
+// migrateAll migrates every election with candidates but no details and
+// returns how many it migrated
+func migrateAll(dal db.DataAccessLayer, c colls) (int, error) {
+	var elections []pb.Election
+	err := dal.FindAll(c.elections, bson.M{}, &elections)
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	for i := range elections {
+		if len(elections[i].GetDetails()) > 0 || len(elections[i].GetCandidates()) == 0 {
+			continue
+		}
+		err = migrate(dal, c, &elections[i])
+		if err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+	return migrated, nil
+}
+
+// migrate gives the candidates of the election ids and details named after
+// them. The ballots are rewritten before the election, so a migration cut
+// short is completed by running it again.
+func migrate(dal db.DataAccessLayer, c colls, e *pb.Election) error {
+	frozen, err := published(dal, c, e.GetId())
+	if err != nil {
+		return err
+	}
+
+	ids := make(map[string]string, len(e.GetCandidates()))
+	for i, name := range e.GetCandidates() {
+		ids[name] = fmt.Sprintf("c%d", i+1)
+	}
+	// names looking like generated ids would be ambiguous
+	for _, name := range e.GetCandidates() {
+		if _, ok := ids[ids[name]]; ok && ids[name] != name {
+			frozen = true
+		}
+	}
+	if frozen {
+		for name := range ids {
+			ids[name] = name
+		}
+	}
+
+	if !frozen && !e.GetSecret() {
+		err = rewriteVotes(dal, c, e.GetId(), ids)
+		if err != nil {
+			return err
+		}
+	}
+
+	details := make([]*pb.Candidate, 0, len(e.GetCandidates()))
+	for _, name := range e.GetCandidates() {
+		details = append(details, &pb.Candidate{Id: ids[name], Name: name})
+	}
+	return dal.Update(c.elections, bson.M{"id": e.GetId()}, bson.M{"$set": bson.M{
+		"candidates": rename(e.GetCandidates(), ids),
+		"details":    details,
+		"withdrawn":  rename(e.GetWithdrawn(), ids),
+		"lot":        rename(e.GetLot(), ids),
+	}})
+}
+
+// published tells whether results or a ballot commitment of the election
+// were published
+func published(dal db.DataAccessLayer, c colls, id int32) (bool, error) {
+	var results pb.SignedResults
+	err := dal.FindOne(c.results, bson.M{"results.electionid": id}, &results)
+	if err != mgo.ErrNotFound {
+		return err == nil, err
+	}
+
+	var commitment pb.Commitment
+	err = dal.FindOne(c.commitments, bson.M{"electionid": id}, &commitment)
+	if err != mgo.ErrNotFound {
+		return err == nil, err
+	}
+	return false, nil
+}
+
+// storedVote is a ballot with the key it is stored under, the receipt for
+// ballots of the processor and an object id for ballots stored before it.
+type storedVote struct {
+	ID      interface{} `bson:"_id"`
+	pb.Vote `bson:",inline"`
+}
+
+// rewriteVotes has the plaintext ballots of the election choose candidates by
+// id, choices of other names such as write-ins are left as they are.
+func rewriteVotes(dal db.DataAccessLayer, c colls, id int32, ids map[string]string) error {
+	var votes []storedVote
+	err := dal.FindAll(c.votes, bson.M{"electionid": id}, &votes)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range votes {
+		set := bson.M{
+			"candidate":  rename([]string{v.GetCandidate()}, ids)[0],
+			"selections": rename(v.GetSelections(), ids),
+			"rankings":   rename(v.GetRankings(), ids),
+		}
+		if len(v.GetScores()) > 0 {
+			scores := make(map[string]int32, len(v.GetScores()))
+			for name, score := range v.GetScores() {
+				scores[rename([]string{name}, ids)[0]] = score
+			}
+			set["scores"] = scores
+		}
+
+		err = dal.Update(c.votes, bson.M{"_id": v.ID}, bson.M{"$set": set})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func rename(names []string, ids map[string]string) []string {
+	if names == nil {
+		return nil
+	}
+	renamed := make([]string, len(names))
+	for i, name := range names {
+		renamed[i] = name
+		if id, ok := ids[name]; ok {
+			renamed[i] = id
+		}
+	}
+	return renamed
+}
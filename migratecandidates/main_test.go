@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/ednesic/vote-test/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func Test_migrateAll(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	c := colls{elections: "election", votes: "vote", commitments: "commitment", results: "results"}
+	open := pb.Election{Id: 1, Candidates: []string{"Jane", "John"}, Withdrawn: []string{"John"}}
+	migrated := pb.Election{Id: 2, Candidates: []string{"c1"}, Details: []*pb.Candidate{{Id: "c1", Name: "Jane"}}}
+	legacyID := bson.NewObjectId()
+	referendum := pb.Election{Id: 3, Contests: []*pb.Contest{{Id: "q1", Candidates: []string{"yes", "no"}}}}
+
+	tests := []struct {
+		name       string
+		resultsRet error
+		commitRet  error
+		votesRet   error
+		wantIDs    []string
+		votes      int
+		wantErr    bool
+	}{
+		{"Open election", mgo.ErrNotFound, mgo.ErrNotFound, nil, []string{"c1", "c2"}, 3, false},
+		{"Election with results", nil, mgo.ErrNotFound, nil, []string{"Jane", "John"}, 0, false},
+		{"Election with commitment", mgo.ErrNotFound, nil, nil, []string{"Jane", "John"}, 0, false},
+		{"Results find fail", errors.New("test error"), mgo.ErrNotFound, nil, nil, 0, true},
+		{"Ballots find fail", mgo.ErrNotFound, mgo.ErrNotFound, errors.New("test error"), nil, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			mgoDal.Calls = nil
+
+			mgoDal.On("FindAll", "election", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				*args.Get(2).(*[]pb.Election) = []pb.Election{open, migrated, referendum}
+			})
+			mgoDal.On("FindOne", "results", mock.Anything, mock.Anything).Return(tt.resultsRet)
+			mgoDal.On("FindOne", "commitment", mock.Anything, mock.Anything).Return(tt.commitRet)
+			mgoDal.On("FindAll", "vote", mock.Anything, mock.Anything).Return(tt.votesRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*[]storedVote) = []storedVote{
+					{ID: "r1", Vote: pb.Vote{Receipt: "r1", Candidate: "Jane"}},
+					{ID: "r2", Vote: pb.Vote{Receipt: "r2", Rankings: []string{"John", "Write In"}, Scores: map[string]int32{"Jane": 3}}},
+					{ID: legacyID, Vote: pb.Vote{Candidate: "John"}},
+				}
+			})
+			var keys []interface{}
+			var sets []bson.M
+			mgoDal.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				keys = append(keys, args.Get(1).(bson.M)["_id"])
+				sets = append(sets, args.Get(2).(bson.M)["$set"].(bson.M))
+			})
+
+			n, err := migrateAll(mgoDal, c)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, 1, n)
+			assert.Len(t, sets, tt.votes+1)
+
+			election := sets[len(sets)-1]
+			assert.Equal(t, tt.wantIDs, election["candidates"])
+			assert.Equal(t, []string{tt.wantIDs[1]}, election["withdrawn"])
+			assert.Equal(t, []*pb.Candidate{{Id: tt.wantIDs[0], Name: "Jane"}, {Id: tt.wantIDs[1], Name: "John"}}, election["details"])
+			if tt.votes == 0 {
+				return
+			}
+			assert.Equal(t, "c1", sets[0]["candidate"])
+			assert.Equal(t, []string{"c2", "Write In"}, sets[1]["rankings"])
+			assert.Equal(t, map[string]int32{"c1": 3}, sets[1]["scores"])
+			assert.Equal(t, "c2", sets[2]["candidate"])
+			assert.Equal(t, []interface{}{"r1", "r2", legacyID}, keys[:tt.votes])
+		})
+	}
+}
+
+func Test_migrate_ambiguous(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	c := colls{elections: "election", votes: "vote", commitments: "commitment", results: "results"}
+
+	mgoDal.On("FindOne", mock.Anything, mock.Anything, mock.Anything).Return(mgo.ErrNotFound)
+	mgoDal.On("Update", "election", bson.M{"id": int32(1)}, mock.Anything).Return(nil)
+
+	err := migrate(mgoDal, c, &pb.Election{Id: 1, Candidates: []string{"c2", "Jane"}})
+	assert.Nil(t, err)
+	mgoDal.AssertNotCalled(t, "FindAll", "vote", mock.Anything, mock.Anything)
+	set := mgoDal.Calls[len(mgoDal.Calls)-1].Arguments.Get(2).(bson.M)["$set"].(bson.M)
+	assert.Equal(t, []string{"c2", "Jane"}, set["candidates"])
+}
@@ -19,21 +19,638 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type VotingMethod int32
+
+const (
+	// One candidate per ballot
+	VotingMethod_PLURALITY VotingMethod = 0
+	// Any number of approved candidates per ballot
+	VotingMethod_APPROVAL VotingMethod = 1
+	// Candidates ordered by preference
+	VotingMethod_RANKED VotingMethod = 2
+	// A score for each candidate
+	VotingMethod_SCORE VotingMethod = 3
+	// Candidates ordered by preference, counted pairwise with the Schulze method
+	VotingMethod_SCHULZE VotingMethod = 4
+	// Candidates ordered by preference, counted by single transferable vote
+	VotingMethod_STV VotingMethod = 5
+	// A score for each candidate, the two highest scored go to a runoff
+	VotingMethod_STAR VotingMethod = 6
+)
+
+var VotingMethod_name = map[int32]string{
+	0: "PLURALITY",
+	1: "APPROVAL",
+	2: "RANKED",
+	3: "SCORE",
+	4: "SCHULZE",
+	5: "STV",
+	6: "STAR",
+}
+var VotingMethod_value = map[string]int32{
+	"PLURALITY": 0,
+	"APPROVAL":  1,
+	"RANKED":    2,
+	"SCORE":     3,
+	"SCHULZE":   4,
+	"STV":       5,
+	"STAR":      6,
+}
+
+func (x VotingMethod) String() string {
+	return proto.EnumName(VotingMethod_name, int32(x))
+}
+func (VotingMethod) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_election_fea01774871c6dcf, []int{0}
+}
+
+// TieBreak decides ties between candidates in the count
+type TieBreak int32
+
+const (
+	// Ties are declared in the results, the count orders tied candidates by name
+	TieBreak_DECLARE TieBreak = 0
+	// Tied candidates are ordered by a shuffle from the seed of the election
+	TieBreak_RANDOM TieBreak = 1
+	// The candidate with the earliest ballot for it wins the tie
+	TieBreak_EARLIEST TieBreak = 2
+	// The lot drawn by the election admin orders tied candidates
+	TieBreak_LOT TieBreak = 3
+)
+
+var TieBreak_name = map[int32]string{
+	0: "DECLARE",
+	1: "RANDOM",
+	2: "EARLIEST",
+	3: "LOT",
+}
+var TieBreak_value = map[string]int32{
+	"DECLARE":  0,
+	"RANDOM":   1,
+	"EARLIEST": 2,
+	"LOT":      3,
+}
+
+func (x TieBreak) String() string {
+	return proto.EnumName(TieBreak_name, int32(x))
+}
+func (TieBreak) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_election_fea01774871c6dcf, []int{1}
+}
+
+// BallotOrder is the order candidates are shown on ballots
+type BallotOrder int32
+
+const (
+	// As listed on the election
+	BallotOrder_FIXED        BallotOrder = 0
+	BallotOrder_ALPHABETICAL BallotOrder = 1
+	// Shuffled per voter from the ballot seed of the election
+	BallotOrder_SHUFFLE BallotOrder = 2
+	// The listed order rotated by an offset per voter
+	BallotOrder_ROTATION BallotOrder = 3
+)
+
+var BallotOrder_name = map[int32]string{
+	0: "FIXED",
+	1: "ALPHABETICAL",
+	2: "SHUFFLE",
+	3: "ROTATION",
+}
+var BallotOrder_value = map[string]int32{
+	"FIXED":        0,
+	"ALPHABETICAL": 1,
+	"SHUFFLE":      2,
+	"ROTATION":     3,
+}
+
+func (x BallotOrder) String() string {
+	return proto.EnumName(BallotOrder_name, int32(x))
+}
+func (BallotOrder) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_election_fea01774871c6dcf, []int{2}
+}
+
+// Withdrawal decides what happens to ballots already cast for a withdrawn
+// candidate
+type Withdrawal int32
+
+const (
+	// The ballots count as cast
+	Withdrawal_KEEP Withdrawal = 0
+	// Ballots choosing a withdrawn candidate are void
+	Withdrawal_VOID Withdrawal = 1
+	// Ranked ballots move on to their next preference
+	Withdrawal_REDISTRIBUTE Withdrawal = 2
+)
+
+var Withdrawal_name = map[int32]string{
+	0: "KEEP",
+	1: "VOID",
+	2: "REDISTRIBUTE",
+}
+var Withdrawal_value = map[string]int32{
+	"KEEP":         0,
+	"VOID":         1,
+	"REDISTRIBUTE": 2,
+}
+
+func (x Withdrawal) String() string {
+	return proto.EnumName(Withdrawal_name, int32(x))
+}
+func (Withdrawal) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_election_fea01774871c6dcf, []int{3}
+}
+
+// Candidate describes a candidate, ballots and results refer to it by its
+// stable id whatever its display name
+type Candidate struct {
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Free form details such as party, bio or photo url
+	Metadata             map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Candidate) Reset()         { *m = Candidate{} }
+func (m *Candidate) String() string { return proto.CompactTextString(m) }
+func (*Candidate) ProtoMessage()    {}
+func (*Candidate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_election_fea01774871c6dcf, []int{0}
+}
+func (m *Candidate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Candidate.Unmarshal(m, b)
+}
+func (m *Candidate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Candidate.Marshal(b, m, deterministic)
+}
+func (dst *Candidate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Candidate.Merge(dst, src)
+}
+func (m *Candidate) XXX_Size() int {
+	return xxx_messageInfo_Candidate.Size(m)
+}
+func (m *Candidate) XXX_DiscardUnknown() {
+	xxx_messageInfo_Candidate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Candidate proto.InternalMessageInfo
+
+func (m *Candidate) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Candidate) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Candidate) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Candidate) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// Labels are the texts of an election in one language, texts left empty
+// fall back to those of the default locale
+type Labels struct {
+	Title       string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Display names by candidate id
+	Candidates map[string]string `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Questions by contest id
+	Questions map[string]string `protobuf:"bytes,4,rep,name=questions,proto3" json:"questions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Display names of the options by contest id
+	Options              map[string]*OptionLabels `protobuf:"bytes,5,rep,name=options,proto3" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *Labels) Reset()         { *m = Labels{} }
+func (m *Labels) String() string { return proto.CompactTextString(m) }
+func (*Labels) ProtoMessage()    {}
+func (*Labels) Descriptor() ([]byte, []int) {
+	return fileDescriptor_election_fea01774871c6dcf, []int{1}
+}
+func (m *Labels) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Labels.Unmarshal(m, b)
+}
+func (m *Labels) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Labels.Marshal(b, m, deterministic)
+}
+func (dst *Labels) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Labels.Merge(dst, src)
+}
+func (m *Labels) XXX_Size() int {
+	return xxx_messageInfo_Labels.Size(m)
+}
+func (m *Labels) XXX_DiscardUnknown() {
+	xxx_messageInfo_Labels.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Labels proto.InternalMessageInfo
+
+func (m *Labels) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *Labels) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Labels) GetCandidates() map[string]string {
+	if m != nil {
+		return m.Candidates
+	}
+	return nil
+}
+
+func (m *Labels) GetQuestions() map[string]string {
+	if m != nil {
+		return m.Questions
+	}
+	return nil
+}
+
+func (m *Labels) GetOptions() map[string]*OptionLabels {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// OptionLabels are the display names of the options of a contest by option
+type OptionLabels struct {
+	Names                map[string]string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *OptionLabels) Reset()         { *m = OptionLabels{} }
+func (m *OptionLabels) String() string { return proto.CompactTextString(m) }
+func (*OptionLabels) ProtoMessage()    {}
+func (*OptionLabels) Descriptor() ([]byte, []int) {
+	return fileDescriptor_election_fea01774871c6dcf, []int{2}
+}
+func (m *OptionLabels) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OptionLabels.Unmarshal(m, b)
+}
+func (m *OptionLabels) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OptionLabels.Marshal(b, m, deterministic)
+}
+func (dst *OptionLabels) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OptionLabels.Merge(dst, src)
+}
+func (m *OptionLabels) XXX_Size() int {
+	return xxx_messageInfo_OptionLabels.Size(m)
+}
+func (m *OptionLabels) XXX_DiscardUnknown() {
+	xxx_messageInfo_OptionLabels.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OptionLabels proto.InternalMessageInfo
+
+func (m *OptionLabels) GetNames() map[string]string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+// Contest is one question of an election with several contests, it has its
+// own options and is counted on its own
+type Contest struct {
+	Id           string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Question     string       `protobuf:"bytes,2,opt,name=question,proto3" json:"question,omitempty"`
+	Candidates   []string     `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	VotingMethod VotingMethod `protobuf:"varint,4,opt,name=voting_method,json=votingMethod,enum=VotingMethod,proto3" json:"voting_method,omitempty"`
+	Seats        int32        `protobuf:"varint,5,opt,name=seats,proto3" json:"seats,omitempty"`
+	MaxScore     int32        `protobuf:"varint,6,opt,name=max_score,json=maxScore,proto3" json:"max_score,omitempty"`
+	WriteIns     bool         `protobuf:"varint,7,opt,name=write_ins,json=writeIns,proto3" json:"write_ins,omitempty"`
+	Abstain      bool         `protobuf:"varint,8,opt,name=abstain,proto3" json:"abstain,omitempty"`
+	// Withdrawn options, the withdrawal policy of the election applies
+	Withdrawn            []string `protobuf:"bytes,9,rep,name=withdrawn,proto3" json:"withdrawn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Contest) Reset()         { *m = Contest{} }
+func (m *Contest) String() string { return proto.CompactTextString(m) }
+func (*Contest) ProtoMessage()    {}
+func (*Contest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_election_fea01774871c6dcf, []int{3}
+}
+func (m *Contest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Contest.Unmarshal(m, b)
+}
+func (m *Contest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Contest.Marshal(b, m, deterministic)
+}
+func (dst *Contest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Contest.Merge(dst, src)
+}
+func (m *Contest) XXX_Size() int {
+	return xxx_messageInfo_Contest.Size(m)
+}
+func (m *Contest) XXX_DiscardUnknown() {
+	xxx_messageInfo_Contest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Contest proto.InternalMessageInfo
+
+func (m *Contest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Contest) GetQuestion() string {
+	if m != nil {
+		return m.Question
+	}
+	return ""
+}
+
+func (m *Contest) GetCandidates() []string {
+	if m != nil {
+		return m.Candidates
+	}
+	return nil
+}
+
+func (m *Contest) GetVotingMethod() VotingMethod {
+	if m != nil {
+		return m.VotingMethod
+	}
+	return VotingMethod_PLURALITY
+}
+
+func (m *Contest) GetSeats() int32 {
+	if m != nil {
+		return m.Seats
+	}
+	return 0
+}
+
+func (m *Contest) GetMaxScore() int32 {
+	if m != nil {
+		return m.MaxScore
+	}
+	return 0
+}
+
+func (m *Contest) GetWriteIns() bool {
+	if m != nil {
+		return m.WriteIns
+	}
+	return false
+}
+
+func (m *Contest) GetAbstain() bool {
+	if m != nil {
+		return m.Abstain
+	}
+	return false
+}
+
+func (m *Contest) GetWithdrawn() []string {
+	if m != nil {
+		return m.Withdrawn
+	}
+	return nil
+}
+
+// Rules decide whether an outcome is valid and passed. Turnout is the weight
+// of all ballots, blank ones included, and must reach the quorum and the
+// quorum percent of the eligible voters. The leader passes with a share of
+// at least majority (more than majority when strict) of the ballot weight,
+// counting blank ballots in that weight only with count_abstentions.
+type Rules struct {
+	Quorum               int64    `protobuf:"varint,1,opt,name=quorum,proto3" json:"quorum,omitempty"`
+	QuorumPercent        float64  `protobuf:"fixed64,2,opt,name=quorum_percent,json=quorumPercent,proto3" json:"quorum_percent,omitempty"`
+	Eligible             int64    `protobuf:"varint,3,opt,name=eligible,proto3" json:"eligible,omitempty"`
+	Majority             float64  `protobuf:"fixed64,4,opt,name=majority,proto3" json:"majority,omitempty"`
+	Strict               bool     `protobuf:"varint,5,opt,name=strict,proto3" json:"strict,omitempty"`
+	CountAbstentions     bool     `protobuf:"varint,6,opt,name=count_abstentions,json=countAbstentions,proto3" json:"count_abstentions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Rules) Reset()         { *m = Rules{} }
+func (m *Rules) String() string { return proto.CompactTextString(m) }
+func (*Rules) ProtoMessage()    {}
+func (*Rules) Descriptor() ([]byte, []int) {
+	return fileDescriptor_election_fea01774871c6dcf, []int{4}
+}
+func (m *Rules) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Rules.Unmarshal(m, b)
+}
+func (m *Rules) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Rules.Marshal(b, m, deterministic)
+}
+func (dst *Rules) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Rules.Merge(dst, src)
+}
+func (m *Rules) XXX_Size() int {
+	return xxx_messageInfo_Rules.Size(m)
+}
+func (m *Rules) XXX_DiscardUnknown() {
+	xxx_messageInfo_Rules.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Rules proto.InternalMessageInfo
+
+func (m *Rules) GetQuorum() int64 {
+	if m != nil {
+		return m.Quorum
+	}
+	return 0
+}
+
+func (m *Rules) GetQuorumPercent() float64 {
+	if m != nil {
+		return m.QuorumPercent
+	}
+	return 0
+}
+
+func (m *Rules) GetEligible() int64 {
+	if m != nil {
+		return m.Eligible
+	}
+	return 0
+}
+
+func (m *Rules) GetMajority() float64 {
+	if m != nil {
+		return m.Majority
+	}
+	return 0
+}
+
+func (m *Rules) GetStrict() bool {
+	if m != nil {
+		return m.Strict
+	}
+	return false
+}
+
+func (m *Rules) GetCountAbstentions() bool {
+	if m != nil {
+		return m.CountAbstentions
+	}
+	return false
+}
+
+// RunoffPolicy starts a plurality runoff between the two leading candidates
+// of a closed election when no candidate reaches the majority share of the
+// votes. Decided is set by the election service once the outcome is known.
+type RunoffPolicy struct {
+	Majority float64 `protobuf:"fixed64,1,opt,name=majority,proto3" json:"majority,omitempty"`
+	// Seconds the runoff election stays open
+	Duration             int64    `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	Decided              bool     `protobuf:"varint,3,opt,name=decided,proto3" json:"decided,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunoffPolicy) Reset()         { *m = RunoffPolicy{} }
+func (m *RunoffPolicy) String() string { return proto.CompactTextString(m) }
+func (*RunoffPolicy) ProtoMessage()    {}
+func (*RunoffPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_election_fea01774871c6dcf, []int{5}
+}
+func (m *RunoffPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunoffPolicy.Unmarshal(m, b)
+}
+func (m *RunoffPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunoffPolicy.Marshal(b, m, deterministic)
+}
+func (dst *RunoffPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunoffPolicy.Merge(dst, src)
+}
+func (m *RunoffPolicy) XXX_Size() int {
+	return xxx_messageInfo_RunoffPolicy.Size(m)
+}
+func (m *RunoffPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunoffPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunoffPolicy proto.InternalMessageInfo
+
+func (m *RunoffPolicy) GetMajority() float64 {
+	if m != nil {
+		return m.Majority
+	}
+	return 0
+}
+
+func (m *RunoffPolicy) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *RunoffPolicy) GetDecided() bool {
+	if m != nil {
+		return m.Decided
+	}
+	return false
+}
+
 type Election struct {
-	Id                   int32                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Start                *timestamp.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
-	End                  *timestamp.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
-	Candidates           []string             `protobuf:"bytes,4,rep,name=candidates,proto3" json:"candidates,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Id    int32                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Start *timestamp.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End   *timestamp.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	// Ids of the candidates, ballots choose candidates by id
+	Candidates []string `protobuf:"bytes,4,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	// Secret elections only accept encrypted ballots
+	Secret bool `protobuf:"varint,5,opt,name=secret,proto3" json:"secret,omitempty"`
+	// ElGamal public key of a secret election, set by the election service
+	EncryptionKey []byte `protobuf:"bytes,6,opt,name=encryption_key,json=encryptionKey,proto3" json:"encryption_key,omitempty"`
+	// Invite only elections require a one-time code on each ballot
+	InviteOnly bool `protobuf:"varint,7,opt,name=invite_only,json=inviteOnly,proto3" json:"invite_only,omitempty"`
+	// Shape ballots must have, secret elections support plurality and approval
+	VotingMethod VotingMethod `protobuf:"varint,8,opt,name=voting_method,json=votingMethod,enum=VotingMethod,proto3" json:"voting_method,omitempty"`
+	// Number of candidates elected, zero means one; only STV elects more
+	Seats int32 `protobuf:"varint,9,opt,name=seats,proto3" json:"seats,omitempty"`
+	// Highest score a ballot can give, required by score and STAR elections
+	MaxScore int32 `protobuf:"varint,10,opt,name=max_score,json=maxScore,proto3" json:"max_score,omitempty"`
+	// Weighted elections count each ballot with the imported weight of its voter
+	Weighted bool `protobuf:"varint,11,opt,name=weighted,proto3" json:"weighted,omitempty"`
+	// Contests of a referendum, replace candidates and voting method
+	Contests []*Contest `protobuf:"bytes,12,rep,name=contests,proto3" json:"contests,omitempty"`
+	// Write-ins allow choosing names that are not candidates
+	WriteIns bool `protobuf:"varint,13,opt,name=write_ins,json=writeIns,proto3" json:"write_ins,omitempty"`
+	// Abstain allows blank ballots, counted in turnout but for no candidate
+	Abstain bool          `protobuf:"varint,14,opt,name=abstain,proto3" json:"abstain,omitempty"`
+	Rules   *Rules        `protobuf:"bytes,15,opt,name=rules,proto3" json:"rules,omitempty"`
+	Runoff  *RunoffPolicy `protobuf:"bytes,16,opt,name=runoff,proto3" json:"runoff,omitempty"`
+	// Election whose runoff this election is
+	Parent int32 `protobuf:"varint,17,opt,name=parent,proto3" json:"parent,omitempty"`
+	// Runoff started for this election, set by the election service
+	RunoffId int32    `protobuf:"varint,18,opt,name=runoff_id,json=runoffId,proto3" json:"runoff_id,omitempty"`
+	TieBreak TieBreak `protobuf:"varint,19,opt,name=tie_break,json=tieBreak,enum=TieBreak,proto3" json:"tie_break,omitempty"`
+	// Seed of random tie-breaks, set by the election service
+	Seed int64 `protobuf:"varint,20,opt,name=seed,proto3" json:"seed,omitempty"`
+	// Candidates in the order of the lot drawn for lot tie-breaks
+	Lot         []string    `protobuf:"bytes,21,rep,name=lot,proto3" json:"lot,omitempty"`
+	BallotOrder BallotOrder `protobuf:"varint,22,opt,name=ballot_order,json=ballotOrder,enum=BallotOrder,proto3" json:"ballot_order,omitempty"`
+	// Seed of shuffled ballots, set by the election service
+	BallotSeed int64 `protobuf:"varint,23,opt,name=ballot_seed,json=ballotSeed,proto3" json:"ballot_seed,omitempty"`
+	// Candidates that dropped out, new ballots can not choose them
+	Withdrawn  []string   `protobuf:"bytes,24,rep,name=withdrawn,proto3" json:"withdrawn,omitempty"`
+	Withdrawal Withdrawal `protobuf:"varint,25,opt,name=withdrawal,enum=Withdrawal,proto3" json:"withdrawal,omitempty"`
+	// Details of the candidates, whose ids the candidates list
+	Details     []*Candidate `protobuf:"bytes,26,rep,name=details,proto3" json:"details,omitempty"`
+	Title       string       `protobuf:"bytes,27,opt,name=title,proto3" json:"title,omitempty"`
+	Description string       `protobuf:"bytes,28,opt,name=description,proto3" json:"description,omitempty"`
+	// BCP 47 tag of the language of the title, description, candidate names
+	// and questions, the election service default locale when empty
+	Locale string `protobuf:"bytes,29,opt,name=locale,proto3" json:"locale,omitempty"`
+	// Translations keyed by BCP 47 tag
+	Labels map[string]*Labels `protobuf:"bytes,30,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Set once the ballot commitment and receipt proofs are stored
+	Committed            bool     `protobuf:"varint,31,opt,name=committed,proto3" json:"committed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Election) Reset()         { *m = Election{} }
 func (m *Election) String() string { return proto.CompactTextString(m) }
 func (*Election) ProtoMessage()    {}
 func (*Election) Descriptor() ([]byte, []int) {
-	return fileDescriptor_election_86eab52e891d543e, []int{0}
+	return fileDescriptor_election_fea01774871c6dcf, []int{6}
 }
 func (m *Election) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Election.Unmarshal(m, b)
@@ -81,23 +698,295 @@ func (m *Election) GetCandidates() []string {
 	return nil
 }
 
+func (m *Election) GetSecret() bool {
+	if m != nil {
+		return m.Secret
+	}
+	return false
+}
+
+func (m *Election) GetEncryptionKey() []byte {
+	if m != nil {
+		return m.EncryptionKey
+	}
+	return nil
+}
+
+func (m *Election) GetInviteOnly() bool {
+	if m != nil {
+		return m.InviteOnly
+	}
+	return false
+}
+
+func (m *Election) GetVotingMethod() VotingMethod {
+	if m != nil {
+		return m.VotingMethod
+	}
+	return VotingMethod_PLURALITY
+}
+
+func (m *Election) GetSeats() int32 {
+	if m != nil {
+		return m.Seats
+	}
+	return 0
+}
+
+func (m *Election) GetMaxScore() int32 {
+	if m != nil {
+		return m.MaxScore
+	}
+	return 0
+}
+
+func (m *Election) GetWeighted() bool {
+	if m != nil {
+		return m.Weighted
+	}
+	return false
+}
+
+func (m *Election) GetContests() []*Contest {
+	if m != nil {
+		return m.Contests
+	}
+	return nil
+}
+
+func (m *Election) GetWriteIns() bool {
+	if m != nil {
+		return m.WriteIns
+	}
+	return false
+}
+
+func (m *Election) GetAbstain() bool {
+	if m != nil {
+		return m.Abstain
+	}
+	return false
+}
+
+func (m *Election) GetRules() *Rules {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *Election) GetRunoff() *RunoffPolicy {
+	if m != nil {
+		return m.Runoff
+	}
+	return nil
+}
+
+func (m *Election) GetParent() int32 {
+	if m != nil {
+		return m.Parent
+	}
+	return 0
+}
+
+func (m *Election) GetRunoffId() int32 {
+	if m != nil {
+		return m.RunoffId
+	}
+	return 0
+}
+
+func (m *Election) GetTieBreak() TieBreak {
+	if m != nil {
+		return m.TieBreak
+	}
+	return TieBreak_DECLARE
+}
+
+func (m *Election) GetSeed() int64 {
+	if m != nil {
+		return m.Seed
+	}
+	return 0
+}
+
+func (m *Election) GetLot() []string {
+	if m != nil {
+		return m.Lot
+	}
+	return nil
+}
+
+func (m *Election) GetBallotOrder() BallotOrder {
+	if m != nil {
+		return m.BallotOrder
+	}
+	return BallotOrder_FIXED
+}
+
+func (m *Election) GetBallotSeed() int64 {
+	if m != nil {
+		return m.BallotSeed
+	}
+	return 0
+}
+
+func (m *Election) GetWithdrawn() []string {
+	if m != nil {
+		return m.Withdrawn
+	}
+	return nil
+}
+
+func (m *Election) GetWithdrawal() Withdrawal {
+	if m != nil {
+		return m.Withdrawal
+	}
+	return Withdrawal_KEEP
+}
+
+func (m *Election) GetDetails() []*Candidate {
+	if m != nil {
+		return m.Details
+	}
+	return nil
+}
+
+func (m *Election) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *Election) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Election) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
+func (m *Election) GetLabels() map[string]*Labels {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Election) GetCommitted() bool {
+	if m != nil {
+		return m.Committed
+	}
+	return false
+}
+
 func init() {
+	proto.RegisterType((*Candidate)(nil), "Candidate")
+	proto.RegisterMapType((map[string]string)(nil), "Candidate.MetadataEntry")
+	proto.RegisterType((*Labels)(nil), "Labels")
+	proto.RegisterMapType((map[string]string)(nil), "Labels.CandidatesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "Labels.QuestionsEntry")
+	proto.RegisterMapType((map[string]*OptionLabels)(nil), "Labels.OptionsEntry")
+	proto.RegisterType((*OptionLabels)(nil), "OptionLabels")
+	proto.RegisterMapType((map[string]string)(nil), "OptionLabels.NamesEntry")
+	proto.RegisterType((*Contest)(nil), "Contest")
+	proto.RegisterType((*Rules)(nil), "Rules")
+	proto.RegisterType((*RunoffPolicy)(nil), "RunoffPolicy")
 	proto.RegisterType((*Election)(nil), "Election")
+	proto.RegisterMapType((map[string]*Labels)(nil), "Election.LabelsEntry")
+	proto.RegisterEnum("VotingMethod", VotingMethod_name, VotingMethod_value)
+	proto.RegisterEnum("TieBreak", TieBreak_name, TieBreak_value)
+	proto.RegisterEnum("BallotOrder", BallotOrder_name, BallotOrder_value)
+	proto.RegisterEnum("Withdrawal", Withdrawal_name, Withdrawal_value)
 }
 
-func init() { proto.RegisterFile("election.proto", fileDescriptor_election_86eab52e891d543e) }
-
-var fileDescriptor_election_86eab52e891d543e = []byte{
-	// 168 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x4b, 0xcd, 0x49, 0x4d,
-	0x2e, 0xc9, 0xcc, 0xcf, 0xd3, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x97, 0x92, 0x4f, 0xcf, 0xcf, 0x4f,
-	0xcf, 0x49, 0xd5, 0x07, 0xf3, 0x92, 0x4a, 0xd3, 0xf4, 0x4b, 0x32, 0x73, 0x53, 0x8b, 0x4b, 0x12,
-	0x73, 0x0b, 0x20, 0x0a, 0x94, 0x66, 0x31, 0x72, 0x71, 0xb8, 0x42, 0xf5, 0x08, 0xf1, 0x71, 0x31,
-	0x65, 0xa6, 0x48, 0x30, 0x2a, 0x30, 0x6a, 0xb0, 0x06, 0x31, 0x65, 0xa6, 0x08, 0x19, 0x70, 0xb1,
-	0x16, 0x97, 0x24, 0x16, 0x95, 0x48, 0x30, 0x29, 0x30, 0x6a, 0x70, 0x1b, 0x49, 0xe9, 0x41, 0x4c,
-	0xd3, 0x83, 0x99, 0xa6, 0x17, 0x02, 0x33, 0x2d, 0x08, 0xa2, 0x50, 0x48, 0x87, 0x8b, 0x39, 0x35,
-	0x2f, 0x45, 0x82, 0x99, 0xa0, 0x7a, 0x90, 0x32, 0x21, 0x39, 0x2e, 0xae, 0xe4, 0xc4, 0xbc, 0x94,
-	0xcc, 0x94, 0xc4, 0x92, 0xd4, 0x62, 0x09, 0x16, 0x05, 0x66, 0x0d, 0xce, 0x20, 0x24, 0x11, 0x27,
-	0x96, 0x28, 0xa6, 0x82, 0xa4, 0x24, 0x36, 0xb0, 0x76, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x11, 0xa8, 0x90, 0x70, 0xdc, 0x00, 0x00, 0x00,
+func init() { proto.RegisterFile("election.proto", fileDescriptor_election_fea01774871c6dcf) }
+
+var fileDescriptor_election_fea01774871c6dcf = []byte{
+	// 1239 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xdb, 0x6e, 0xdb, 0x46,
+	0x13, 0x36, 0x45, 0x1d, 0xa8, 0x91, 0xe4, 0x30, 0xfb, 0x27, 0xce, 0xfe, 0xca, 0xc9, 0x70, 0x93,
+	0xc2, 0x70, 0x5a, 0xa6, 0x70, 0x03, 0x34, 0x48, 0xdb, 0x0b, 0xd9, 0x66, 0x10, 0x21, 0x4a, 0xa4,
+	0xae, 0x64, 0xb7, 0xcd, 0x8d, 0x4a, 0x91, 0x1b, 0x67, 0x1b, 0x8a, 0x54, 0xc8, 0x95, 0x1d, 0x3d,
+	0x46, 0x9f, 0xa2, 0x0f, 0xd2, 0xa2, 0xcf, 0x55, 0xec, 0x81, 0x22, 0x25, 0x35, 0x01, 0x7c, 0xb7,
+	0xf3, 0xcd, 0xcc, 0xce, 0xec, 0xec, 0x37, 0x33, 0xb0, 0x4d, 0x43, 0xea, 0x73, 0x16, 0x47, 0xce,
+	0x2c, 0x89, 0x79, 0xdc, 0xbe, 0x7f, 0x1e, 0xc7, 0xe7, 0x21, 0x7d, 0x2c, 0xa5, 0xc9, 0xfc, 0xed,
+	0x63, 0xce, 0xa6, 0x34, 0xe5, 0xde, 0x74, 0xa6, 0x0c, 0xf6, 0xfe, 0x36, 0xa0, 0x7e, 0xec, 0x45,
+	0x01, 0x0b, 0x3c, 0x4e, 0xd1, 0x36, 0x94, 0x58, 0x80, 0x8d, 0x5d, 0x63, 0xbf, 0x4e, 0x4a, 0x2c,
+	0x40, 0x08, 0xca, 0x91, 0x37, 0xa5, 0xb8, 0x24, 0x11, 0x79, 0x46, 0xbb, 0xd0, 0x08, 0x68, 0xea,
+	0x27, 0x6c, 0x26, 0xe2, 0x60, 0x53, 0xaa, 0x8a, 0x10, 0x7a, 0x02, 0xd6, 0x94, 0x72, 0x2f, 0xf0,
+	0xb8, 0x87, 0xcb, 0xbb, 0xe6, 0x7e, 0xe3, 0x10, 0x3b, 0xcb, 0x18, 0xce, 0x2b, 0xad, 0x72, 0x23,
+	0x9e, 0x2c, 0xc8, 0xd2, 0xb2, 0xfd, 0x3d, 0xb4, 0x56, 0x54, 0xc8, 0x06, 0xf3, 0x3d, 0x5d, 0xe8,
+	0x6c, 0xc4, 0x11, 0xdd, 0x80, 0xca, 0x85, 0x17, 0xce, 0xb3, 0x7c, 0x94, 0xf0, 0xac, 0xf4, 0xd4,
+	0xd8, 0xfb, 0xd3, 0x84, 0x6a, 0xcf, 0x9b, 0xd0, 0x30, 0x15, 0x46, 0x9c, 0xf1, 0x90, 0x6a, 0x47,
+	0x25, 0xac, 0x67, 0x5d, 0xda, 0xcc, 0xfa, 0x3b, 0x00, 0x3f, 0x4b, 0x32, 0xc5, 0xa6, 0xcc, 0xfb,
+	0x96, 0xa3, 0x2e, 0xcd, 0xd3, 0x4f, 0x55, 0xda, 0x05, 0x53, 0xf4, 0x04, 0xea, 0x1f, 0xe6, 0x34,
+	0x15, 0x97, 0xa4, 0xfa, 0xbd, 0x3b, 0x99, 0xdf, 0x4f, 0x99, 0x42, 0xb9, 0xe5, 0x86, 0xc8, 0x81,
+	0x5a, 0x3c, 0x53, 0x3e, 0x15, 0xe9, 0x73, 0x23, 0xf3, 0xe9, 0xcf, 0x0a, 0x1e, 0x99, 0x51, 0xfb,
+	0x47, 0xb8, 0xb6, 0x96, 0xc4, 0x55, 0x0a, 0xd4, 0xfe, 0x01, 0xb6, 0x57, 0x73, 0xb9, 0x92, 0x77,
+	0x17, 0x9a, 0xfd, 0xd9, 0x67, 0x7d, 0xbf, 0x28, 0xfa, 0x36, 0x0e, 0x5b, 0xfa, 0x15, 0xea, 0x49,
+	0xc5, 0x9f, 0xfa, 0x08, 0xcd, 0xa2, 0x0a, 0x39, 0x50, 0x11, 0xb4, 0x4a, 0xb1, 0xa1, 0x99, 0x52,
+	0xd4, 0x3a, 0xaf, 0x85, 0x4a, 0x55, 0x42, 0x99, 0xb5, 0x9f, 0x02, 0xe4, 0xe0, 0x95, 0x38, 0xf2,
+	0x47, 0x09, 0x6a, 0xc7, 0x71, 0xc4, 0x69, 0xca, 0x37, 0x88, 0xde, 0x06, 0x2b, 0xfb, 0x1a, 0xed,
+	0xb8, 0x94, 0xd1, 0xbd, 0x0d, 0x62, 0xd4, 0x57, 0xfe, 0xff, 0x10, 0x5a, 0x17, 0x31, 0x67, 0xd1,
+	0xf9, 0x78, 0x4a, 0xf9, 0xbb, 0x38, 0xc0, 0xe5, 0x5d, 0x63, 0x7f, 0xfb, 0xb0, 0xe5, 0x9c, 0x49,
+	0xf4, 0x95, 0x04, 0x49, 0xf3, 0xa2, 0x20, 0x89, 0x2c, 0x53, 0xea, 0x71, 0xf1, 0xf7, 0xc6, 0x7e,
+	0x85, 0x28, 0x01, 0xdd, 0x86, 0xfa, 0xd4, 0xfb, 0x38, 0x4e, 0xfd, 0x38, 0xa1, 0xb8, 0x2a, 0x35,
+	0xd6, 0xd4, 0xfb, 0x38, 0x14, 0xb2, 0x50, 0x5e, 0x26, 0x8c, 0xd3, 0x31, 0x8b, 0x52, 0x5c, 0xdb,
+	0x35, 0xf6, 0x2d, 0x62, 0x49, 0xa0, 0x1b, 0xa5, 0x08, 0x43, 0xcd, 0x9b, 0xa4, 0xdc, 0x63, 0x11,
+	0xb6, 0xa4, 0x2a, 0x13, 0xd1, 0x1d, 0xa8, 0x5f, 0x32, 0xfe, 0x2e, 0x48, 0xbc, 0xcb, 0x08, 0xd7,
+	0x65, 0xf2, 0x39, 0xb0, 0xf7, 0x97, 0x01, 0x15, 0x32, 0x0f, 0x69, 0x8a, 0x76, 0xa0, 0xfa, 0x61,
+	0x1e, 0x27, 0xf3, 0xa9, 0xac, 0x8a, 0x49, 0xb4, 0x84, 0x1e, 0xc2, 0xb6, 0x3a, 0x8d, 0x67, 0x34,
+	0xf1, 0x69, 0xc4, 0x65, 0x7d, 0x0c, 0xd2, 0x52, 0xe8, 0x40, 0x81, 0xa2, 0x80, 0x34, 0x64, 0xe7,
+	0x6c, 0x12, 0x52, 0x39, 0x12, 0x4c, 0xb2, 0x94, 0x85, 0x6e, 0xea, 0xfd, 0x1e, 0x27, 0x8c, 0x2f,
+	0x64, 0x6d, 0x0c, 0xb2, 0x94, 0x45, 0xd8, 0x94, 0x27, 0xcc, 0xe7, 0xb2, 0x12, 0x16, 0xd1, 0x12,
+	0x7a, 0x04, 0xd7, 0xfd, 0x78, 0x1e, 0xf1, 0xb1, 0x78, 0x07, 0x8d, 0x54, 0xa3, 0x54, 0xa5, 0x89,
+	0x2d, 0x15, 0x9d, 0x1c, 0xdf, 0xfb, 0x0d, 0x9a, 0x64, 0x1e, 0xc5, 0x6f, 0xdf, 0x0e, 0xe2, 0x90,
+	0xf9, 0x8b, 0x95, 0x80, 0xc6, 0x5a, 0xc0, 0x36, 0x58, 0xc1, 0x3c, 0xf1, 0x96, 0x3f, 0x6d, 0x92,
+	0xa5, 0x2c, 0xaa, 0x18, 0x50, 0x9f, 0x05, 0x34, 0x90, 0x6f, 0xb0, 0x48, 0x26, 0xee, 0xfd, 0x63,
+	0x81, 0xe5, 0xea, 0xd1, 0x5a, 0x20, 0x4f, 0x45, 0x92, 0xe7, 0x1b, 0xa8, 0xa4, 0xdc, 0x4b, 0xb8,
+	0xe6, 0x7e, 0xdb, 0x51, 0x43, 0xd7, 0xc9, 0x86, 0xae, 0x33, 0xca, 0x86, 0x2e, 0x51, 0x86, 0xe8,
+	0x2b, 0x30, 0x69, 0xa4, 0x82, 0x7c, 0xde, 0x5e, 0x98, 0xad, 0x11, 0xb0, 0xbc, 0x41, 0x40, 0x51,
+	0x43, 0xea, 0x27, 0x34, 0xaf, 0xa1, 0x94, 0xc4, 0xd7, 0xd1, 0xc8, 0x4f, 0x16, 0xb2, 0xa1, 0xc6,
+	0xa2, 0x4f, 0x44, 0x01, 0x9b, 0xa4, 0x95, 0xa3, 0x2f, 0xe9, 0x02, 0xdd, 0x87, 0x06, 0x8b, 0x2e,
+	0x04, 0xb3, 0xe2, 0x28, 0x5c, 0x68, 0x6a, 0x81, 0x82, 0xfa, 0x51, 0xb8, 0xd8, 0x24, 0xb8, 0x75,
+	0x05, 0x82, 0xd7, 0x3f, 0x49, 0x70, 0x58, 0x23, 0x78, 0x1b, 0xac, 0x4b, 0xca, 0xce, 0xdf, 0x71,
+	0x1a, 0xe0, 0x86, 0xe6, 0xb7, 0x96, 0xd1, 0x03, 0xb0, 0x7c, 0xd5, 0xba, 0x29, 0x6e, 0xca, 0x41,
+	0x61, 0x39, 0xba, 0x97, 0xc9, 0x52, 0xb3, 0xda, 0x22, 0xad, 0x4f, 0xb7, 0xc8, 0xf6, 0x7a, 0x8b,
+	0x54, 0x12, 0xd1, 0x03, 0xf8, 0x9a, 0xfc, 0x8f, 0xaa, 0x23, 0x3b, 0x82, 0x28, 0x10, 0x3d, 0x84,
+	0x6a, 0x22, 0xc9, 0x85, 0x6d, 0x3d, 0xda, 0x8a, 0x5c, 0x23, 0x5a, 0x29, 0x3e, 0x61, 0xe6, 0x25,
+	0xa2, 0x3f, 0xae, 0xcb, 0x77, 0x69, 0x49, 0xe4, 0xa4, 0x2c, 0xc6, 0x2c, 0xc0, 0x48, 0x3d, 0x59,
+	0x01, 0xdd, 0x00, 0x7d, 0x09, 0x75, 0xce, 0xe8, 0x78, 0x92, 0x50, 0xef, 0x3d, 0xfe, 0x9f, 0xac,
+	0x6a, 0xdd, 0x19, 0x31, 0x7a, 0x24, 0x00, 0x62, 0x71, 0x7d, 0x12, 0x7b, 0x38, 0xa5, 0x34, 0xc0,
+	0x37, 0x24, 0x61, 0xe5, 0x59, 0x8c, 0xbe, 0x30, 0xe6, 0xf8, 0xa6, 0xa4, 0x83, 0x38, 0xa2, 0xc7,
+	0xd0, 0x9c, 0x78, 0x61, 0x18, 0xf3, 0x71, 0x9c, 0x04, 0x34, 0xc1, 0x3b, 0xf2, 0xc2, 0xa6, 0x73,
+	0x24, 0xc1, 0xbe, 0xc0, 0x48, 0x63, 0x92, 0x0b, 0xe2, 0xe7, 0xb5, 0x83, 0xbc, 0xfd, 0x96, 0xbc,
+	0x1d, 0x14, 0x34, 0x14, 0x31, 0x56, 0x86, 0x07, 0x5e, 0x1b, 0x1e, 0xe8, 0x11, 0x40, 0x26, 0x78,
+	0x21, 0xfe, 0xbf, 0x8c, 0xd6, 0x70, 0x7e, 0x5e, 0x42, 0xa4, 0xa0, 0x46, 0x0f, 0x44, 0x6f, 0x71,
+	0x8f, 0x85, 0x29, 0x6e, 0xcb, 0x0f, 0x84, 0x7c, 0xa9, 0x92, 0x4c, 0x95, 0x2f, 0xef, 0xdb, 0x9f,
+	0x59, 0xde, 0x77, 0x36, 0x97, 0xf7, 0x0e, 0x54, 0xc3, 0xd8, 0xf7, 0x42, 0x8a, 0xef, 0x4a, 0xa5,
+	0x96, 0xd0, 0xd7, 0x50, 0x0d, 0xe5, 0x26, 0xc1, 0xf7, 0x64, 0xd0, 0x9b, 0x4e, 0xd6, 0xc5, 0x7a,
+	0xdb, 0xaa, 0xdd, 0xa2, 0x8d, 0xc4, 0x7b, 0xfd, 0x78, 0x3a, 0x65, 0x5c, 0x70, 0xf0, 0xbe, 0x64,
+	0x49, 0x0e, 0xb4, 0x8f, 0xa0, 0x51, 0x70, 0xfa, 0x8f, 0xdd, 0x73, 0x77, 0x75, 0x09, 0xd6, 0x9c,
+	0x8d, 0xf5, 0x77, 0x30, 0x81, 0x66, 0xb1, 0x6b, 0x50, 0x0b, 0xea, 0x83, 0xde, 0x29, 0xe9, 0xf4,
+	0xba, 0xa3, 0x5f, 0xed, 0x2d, 0xd4, 0x04, 0xab, 0x33, 0x18, 0x90, 0xfe, 0x59, 0xa7, 0x67, 0x1b,
+	0x08, 0xa0, 0x4a, 0x3a, 0xaf, 0x5f, 0xba, 0x27, 0x76, 0x09, 0xd5, 0xa1, 0x32, 0x3c, 0xee, 0x13,
+	0xd7, 0x36, 0x51, 0x03, 0x6a, 0xc3, 0xe3, 0x17, 0xa7, 0xbd, 0x37, 0xae, 0x5d, 0x46, 0x35, 0x30,
+	0x87, 0xa3, 0x33, 0xbb, 0x82, 0x2c, 0x28, 0x0f, 0x47, 0x1d, 0x62, 0x57, 0x0f, 0x9e, 0x81, 0x95,
+	0x71, 0x48, 0xd8, 0x9e, 0xb8, 0xc7, 0xbd, 0x0e, 0x71, 0xed, 0x2d, 0x7d, 0xdf, 0x49, 0xff, 0x95,
+	0x6d, 0x88, 0x48, 0x6e, 0x87, 0xf4, 0xba, 0xee, 0x70, 0x64, 0x97, 0xc4, 0x2d, 0xbd, 0xfe, 0xc8,
+	0x36, 0x0f, 0x5c, 0x68, 0x14, 0xe8, 0x22, 0xa2, 0x3e, 0xef, 0xfe, 0xe2, 0x9e, 0xd8, 0x5b, 0xc8,
+	0x86, 0x66, 0xa7, 0x37, 0x78, 0xd1, 0x39, 0x72, 0x47, 0xdd, 0x63, 0x99, 0x9e, 0xc8, 0xe3, 0xc5,
+	0xe9, 0xf3, 0xe7, 0x3d, 0xd7, 0x2e, 0x89, 0xfb, 0x48, 0x7f, 0xd4, 0x19, 0x75, 0xfb, 0xaf, 0x6d,
+	0xf3, 0xe0, 0x10, 0x20, 0xe7, 0x81, 0x48, 0xed, 0xa5, 0xeb, 0x0e, 0xec, 0x2d, 0x71, 0x3a, 0xeb,
+	0x77, 0x4f, 0x6c, 0x43, 0x5c, 0x47, 0xdc, 0x93, 0xee, 0x70, 0x44, 0xba, 0x47, 0xa7, 0x23, 0xd7,
+	0x2e, 0x1d, 0x95, 0xdf, 0x94, 0x66, 0x93, 0x49, 0x55, 0x4e, 0xc1, 0x6f, 0xff, 0x1d, 0x00, 0x59,
+	0x7a, 0xb0, 0xf0, 0xca, 0x0a, 0x00, 0x00,
 }
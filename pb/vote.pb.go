@@ -6,6 +6,7 @@ package pb
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -18,19 +19,124 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// Answer is the choice of a ballot in one contest, in the shape of the
+// contest voting method
+type Answer struct {
+	Contest              string           `protobuf:"bytes,1,opt,name=contest,proto3" json:"contest,omitempty"`
+	Candidate            string           `protobuf:"bytes,2,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	Selections           []string         `protobuf:"bytes,3,rep,name=selections,proto3" json:"selections,omitempty"`
+	Rankings             []string         `protobuf:"bytes,4,rep,name=rankings,proto3" json:"rankings,omitempty"`
+	Scores               map[string]int32 `protobuf:"bytes,5,rep,name=scores,proto3" json:"scores,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Abstain              bool             `protobuf:"varint,6,opt,name=abstain,proto3" json:"abstain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Answer) Reset()         { *m = Answer{} }
+func (m *Answer) String() string { return proto.CompactTextString(m) }
+func (*Answer) ProtoMessage()    {}
+func (*Answer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vote_595f8c11d903d8f0, []int{0}
+}
+func (m *Answer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Answer.Unmarshal(m, b)
+}
+func (m *Answer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Answer.Marshal(b, m, deterministic)
+}
+func (dst *Answer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Answer.Merge(dst, src)
+}
+func (m *Answer) XXX_Size() int {
+	return xxx_messageInfo_Answer.Size(m)
+}
+func (m *Answer) XXX_DiscardUnknown() {
+	xxx_messageInfo_Answer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Answer proto.InternalMessageInfo
+
+func (m *Answer) GetContest() string {
+	if m != nil {
+		return m.Contest
+	}
+	return ""
+}
+
+func (m *Answer) GetCandidate() string {
+	if m != nil {
+		return m.Candidate
+	}
+	return ""
+}
+
+func (m *Answer) GetSelections() []string {
+	if m != nil {
+		return m.Selections
+	}
+	return nil
+}
+
+func (m *Answer) GetRankings() []string {
+	if m != nil {
+		return m.Rankings
+	}
+	return nil
+}
+
+func (m *Answer) GetScores() map[string]int32 {
+	if m != nil {
+		return m.Scores
+	}
+	return nil
+}
+
+func (m *Answer) GetAbstain() bool {
+	if m != nil {
+		return m.Abstain
+	}
+	return false
+}
+
 type Vote struct {
-	ElectionId           int32    `protobuf:"varint,1,opt,name=ElectionId,proto3" json:"ElectionId,omitempty"`
-	Candidate            string   `protobuf:"bytes,2,opt,name=candidate,proto3" json:"candidate,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ElectionId int32  `protobuf:"varint,1,opt,name=ElectionId,proto3" json:"ElectionId,omitempty"`
+	Candidate  string `protobuf:"bytes,2,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	Receipt    string `protobuf:"bytes,3,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	// One ciphertext of 0 or 1 per election candidate, replaces candidate on secret elections
+	Encrypted []*Ciphertext `protobuf:"bytes,4,rep,name=encrypted,proto3" json:"encrypted,omitempty"`
+	// Identity of the voter, never stored with the ballot
+	Voter string `protobuf:"bytes,5,opt,name=voter,proto3" json:"voter,omitempty"`
+	// One-time code required by invite only elections
+	Code string `protobuf:"bytes,6,opt,name=code,proto3" json:"code,omitempty"`
+	// Approved candidates of approval ballots
+	Selections []string `protobuf:"bytes,7,rep,name=selections,proto3" json:"selections,omitempty"`
+	// Candidates by preference of ranked ballots, most preferred first
+	Rankings []string `protobuf:"bytes,8,rep,name=rankings,proto3" json:"rankings,omitempty"`
+	// Score per candidate of score ballots
+	Scores map[string]int32 `protobuf:"bytes,9,rep,name=scores,proto3" json:"scores,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Weight of the voter on weighted elections, set by the vote processor
+	Weight int64 `protobuf:"varint,10,opt,name=weight,proto3" json:"weight,omitempty"`
+	// Answers to the contests of a referendum
+	Answers []*Answer `protobuf:"bytes,11,rep,name=answers,proto3" json:"answers,omitempty"`
+	// Blank ballot of elections allowing abstentions
+	Abstain bool `protobuf:"varint,12,opt,name=abstain,proto3" json:"abstain,omitempty"`
+	// Time the ballot was stored, only kept for earliest vote tie-breaks
+	Cast *timestamp.Timestamp `protobuf:"bytes,13,opt,name=cast,proto3" json:"cast,omitempty"`
+	// Proof that each encrypted choice is 0 or 1, in the order of encrypted
+	ChoiceProofs []*ChoiceProof `protobuf:"bytes,14,rep,name=choiceProofs,proto3" json:"choiceProofs,omitempty"`
+	// Proof that the encrypted choices of a plurality ballot sum to 1
+	SumProof             *SumProof `protobuf:"bytes,15,opt,name=sumProof,proto3" json:"sumProof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *Vote) Reset()         { *m = Vote{} }
 func (m *Vote) String() string { return proto.CompactTextString(m) }
 func (*Vote) ProtoMessage()    {}
 func (*Vote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_vote_57d13796d0675749, []int{0}
+	return fileDescriptor_vote_595f8c11d903d8f0, []int{1}
 }
 func (m *Vote) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Vote.Unmarshal(m, b)
@@ -64,19 +170,188 @@ func (m *Vote) GetCandidate() string {
 	return ""
 }
 
+func (m *Vote) GetReceipt() string {
+	if m != nil {
+		return m.Receipt
+	}
+	return ""
+}
+
+func (m *Vote) GetEncrypted() []*Ciphertext {
+	if m != nil {
+		return m.Encrypted
+	}
+	return nil
+}
+
+func (m *Vote) GetVoter() string {
+	if m != nil {
+		return m.Voter
+	}
+	return ""
+}
+
+func (m *Vote) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *Vote) GetSelections() []string {
+	if m != nil {
+		return m.Selections
+	}
+	return nil
+}
+
+func (m *Vote) GetRankings() []string {
+	if m != nil {
+		return m.Rankings
+	}
+	return nil
+}
+
+func (m *Vote) GetScores() map[string]int32 {
+	if m != nil {
+		return m.Scores
+	}
+	return nil
+}
+
+func (m *Vote) GetWeight() int64 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *Vote) GetAnswers() []*Answer {
+	if m != nil {
+		return m.Answers
+	}
+	return nil
+}
+
+func (m *Vote) GetAbstain() bool {
+	if m != nil {
+		return m.Abstain
+	}
+	return false
+}
+
+func (m *Vote) GetCast() *timestamp.Timestamp {
+	if m != nil {
+		return m.Cast
+	}
+	return nil
+}
+
+func (m *Vote) GetChoiceProofs() []*ChoiceProof {
+	if m != nil {
+		return m.ChoiceProofs
+	}
+	return nil
+}
+
+func (m *Vote) GetSumProof() *SumProof {
+	if m != nil {
+		return m.SumProof
+	}
+	return nil
+}
+
+// VoteEvent is the message published for the vote processor, it carries the
+// trace context of the request that cast the vote apart from the ballot
+type VoteEvent struct {
+	Vote                 *Vote             `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote,omitempty"`
+	Trace                map[string]string `protobuf:"bytes,2,rep,name=trace,proto3" json:"trace,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *VoteEvent) Reset()         { *m = VoteEvent{} }
+func (m *VoteEvent) String() string { return proto.CompactTextString(m) }
+func (*VoteEvent) ProtoMessage()    {}
+func (*VoteEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_vote_595f8c11d903d8f0, []int{2}
+}
+func (m *VoteEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VoteEvent.Unmarshal(m, b)
+}
+func (m *VoteEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VoteEvent.Marshal(b, m, deterministic)
+}
+func (dst *VoteEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VoteEvent.Merge(dst, src)
+}
+func (m *VoteEvent) XXX_Size() int {
+	return xxx_messageInfo_VoteEvent.Size(m)
+}
+func (m *VoteEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_VoteEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VoteEvent proto.InternalMessageInfo
+
+func (m *VoteEvent) GetVote() *Vote {
+	if m != nil {
+		return m.Vote
+	}
+	return nil
+}
+
+func (m *VoteEvent) GetTrace() map[string]string {
+	if m != nil {
+		return m.Trace
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterType((*Answer)(nil), "Answer")
+	proto.RegisterMapType((map[string]int32)(nil), "Answer.ScoresEntry")
 	proto.RegisterType((*Vote)(nil), "Vote")
+	proto.RegisterMapType((map[string]int32)(nil), "Vote.ScoresEntry")
+	proto.RegisterType((*VoteEvent)(nil), "VoteEvent")
+	proto.RegisterMapType((map[string]string)(nil), "VoteEvent.TraceEntry")
 }
 
-func init() { proto.RegisterFile("vote.proto", fileDescriptor_vote_57d13796d0675749) }
+func init() { proto.RegisterFile("vote.proto", fileDescriptor_vote_595f8c11d903d8f0) }
 
-var fileDescriptor_vote_57d13796d0675749 = []byte{
-	// 100 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2a, 0xcb, 0x2f, 0x49,
-	0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x57, 0x72, 0xe1, 0x62, 0x09, 0xcb, 0x2f, 0x49, 0x15, 0x92,
-	0xe3, 0xe2, 0x72, 0xcd, 0x49, 0x4d, 0x2e, 0xc9, 0xcc, 0xcf, 0xf3, 0x4c, 0x91, 0x60, 0x54, 0x60,
-	0xd4, 0x60, 0x0d, 0x42, 0x12, 0x11, 0x92, 0xe1, 0xe2, 0x4c, 0x4e, 0xcc, 0x4b, 0xc9, 0x4c, 0x49,
-	0x2c, 0x49, 0x95, 0x60, 0x52, 0x60, 0xd4, 0xe0, 0x0c, 0x42, 0x08, 0x38, 0xb1, 0x44, 0x31, 0x15,
-	0x24, 0x25, 0xb1, 0x81, 0x8d, 0x34, 0x06, 0x04, 0x00, 0x00, 0xff, 0xff, 0xf9, 0xf7, 0x1c, 0x45,
-	0x60, 0x00, 0x00, 0x00,
+var fileDescriptor_vote_595f8c11d903d8f0 = []byte{
+	// 509 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x52, 0x4b, 0x6f, 0xd3, 0x40,
+	0x10, 0x96, 0x6b, 0x3b, 0x89, 0xc7, 0x29, 0x8f, 0xe5, 0xa1, 0x25, 0x42, 0x60, 0x2a, 0x21, 0xb9,
+	0xaa, 0xb4, 0x45, 0xe1, 0x52, 0xb8, 0x41, 0x95, 0x03, 0x37, 0xb4, 0xad, 0x38, 0x70, 0xdb, 0x6c,
+	0xa6, 0x89, 0xd5, 0xc4, 0x6b, 0xed, 0x6e, 0x52, 0xf2, 0x23, 0xf8, 0x35, 0xfc, 0x3f, 0x84, 0x3c,
+	0x76, 0x1e, 0xad, 0x10, 0x3d, 0x70, 0xdb, 0xef, 0x9b, 0xc7, 0x7e, 0xf3, 0xcd, 0x00, 0xac, 0x8c,
+	0x47, 0x51, 0x59, 0xe3, 0xcd, 0xe0, 0x10, 0xe7, 0x53, 0xb5, 0x50, 0xf3, 0x16, 0xbe, 0x9e, 0x1a,
+	0x33, 0x9d, 0xe3, 0x29, 0xa1, 0xf1, 0xf2, 0xea, 0xd4, 0x17, 0x0b, 0x74, 0x5e, 0x2d, 0xaa, 0x26,
+	0xe1, 0xe8, 0x77, 0x00, 0x9d, 0x4f, 0xa5, 0xbb, 0x41, 0xcb, 0x38, 0x74, 0xb5, 0x29, 0x3d, 0x3a,
+	0xcf, 0x83, 0x2c, 0xc8, 0x13, 0xb9, 0x81, 0xec, 0x25, 0x24, 0x5a, 0x95, 0x93, 0x62, 0xa2, 0x3c,
+	0xf2, 0x03, 0x8a, 0xed, 0x08, 0xf6, 0x0a, 0xc0, 0xe1, 0x1c, 0xb5, 0x2f, 0x4c, 0xe9, 0x78, 0x98,
+	0x85, 0x79, 0x22, 0xf7, 0x18, 0x36, 0x80, 0x9e, 0x55, 0xe5, 0x75, 0x51, 0x4e, 0x1d, 0x8f, 0x28,
+	0xba, 0xc5, 0xec, 0x04, 0x3a, 0x4e, 0x1b, 0x8b, 0x8e, 0xc7, 0x59, 0x98, 0xa7, 0xc3, 0x27, 0xa2,
+	0x11, 0x23, 0x2e, 0x88, 0x1d, 0x95, 0xde, 0xae, 0x65, 0x9b, 0x52, 0x0b, 0x54, 0x63, 0xe7, 0x55,
+	0x51, 0xf2, 0x4e, 0x16, 0xe4, 0x3d, 0xb9, 0x81, 0x83, 0x0f, 0x90, 0xee, 0x15, 0xb0, 0x47, 0x10,
+	0x5e, 0xe3, 0xba, 0x9d, 0xa2, 0x7e, 0xb2, 0xa7, 0x10, 0xaf, 0xd4, 0x7c, 0xd9, 0xa8, 0x8f, 0x65,
+	0x03, 0x3e, 0x1e, 0x9c, 0x05, 0x47, 0xbf, 0x22, 0x88, 0xbe, 0x99, 0x66, 0x8c, 0x51, 0xab, 0xf9,
+	0xcb, 0x84, 0x6a, 0x63, 0xb9, 0xc7, 0xdc, 0x63, 0x02, 0x87, 0xae, 0x45, 0x8d, 0x45, 0xe5, 0x79,
+	0xd8, 0x98, 0xd7, 0x42, 0x76, 0x0c, 0x09, 0x96, 0xda, 0xae, 0x2b, 0x8f, 0x13, 0x9a, 0x3f, 0x1d,
+	0xa6, 0xe2, 0xbc, 0xa8, 0x66, 0x68, 0x3d, 0xfe, 0xf0, 0x72, 0x17, 0x25, 0x95, 0xc6, 0xa3, 0xe5,
+	0x31, 0xb5, 0x68, 0x00, 0x63, 0x10, 0x69, 0x33, 0x41, 0x9a, 0x39, 0x91, 0xf4, 0xbe, 0xe3, 0x79,
+	0xf7, 0x9f, 0x9e, 0xf7, 0xee, 0x78, 0x7e, 0xbc, 0xf5, 0x3c, 0x21, 0x35, 0x8f, 0x45, 0x3d, 0xff,
+	0x5f, 0x1d, 0x7f, 0x0e, 0x9d, 0x1b, 0x2c, 0xa6, 0x33, 0xcf, 0x21, 0x0b, 0xf2, 0x50, 0xb6, 0x88,
+	0xbd, 0x81, 0xae, 0xa2, 0x3d, 0x39, 0x9e, 0x52, 0x8f, 0x6e, 0xbb, 0x37, 0xb9, 0xe1, 0xf7, 0x97,
+	0xd5, 0xbf, 0xb5, 0x2c, 0x26, 0x20, 0xd2, 0xca, 0x79, 0x7e, 0x98, 0x05, 0x79, 0x3a, 0x1c, 0x88,
+	0xe6, 0x44, 0xc5, 0xe6, 0x44, 0xc5, 0xe5, 0xe6, 0x44, 0x25, 0xe5, 0xb1, 0x77, 0xd0, 0xd7, 0x33,
+	0x53, 0x68, 0xfc, 0x6a, 0x8d, 0xb9, 0x72, 0xfc, 0x01, 0xfd, 0xd8, 0x17, 0xe7, 0x3b, 0x52, 0xde,
+	0xca, 0x60, 0x6f, 0xa1, 0xe7, 0x96, 0x0b, 0x02, 0xfc, 0x21, 0xfd, 0x92, 0x88, 0x8b, 0x96, 0x90,
+	0xdb, 0xd0, 0xff, 0x5c, 0xcd, 0xcf, 0x00, 0x92, 0xda, 0xb5, 0xd1, 0x0a, 0x4b, 0xcf, 0x5e, 0x40,
+	0x54, 0xaf, 0x8a, 0x4a, 0xd3, 0x61, 0x4c, 0x7e, 0x4a, 0xa2, 0xd8, 0x09, 0xc4, 0xde, 0x2a, 0x5d,
+	0xb7, 0xa8, 0x55, 0x3f, 0x13, 0xdb, 0x2a, 0x71, 0x59, 0xf3, 0x8d, 0xdf, 0x4d, 0xce, 0xe0, 0x0c,
+	0x60, 0x47, 0xde, 0xa7, 0x27, 0xd9, 0xd3, 0xf3, 0x39, 0xfa, 0x7e, 0x50, 0x8d, 0xc7, 0x1d, 0xf2,
+	0xf0, 0xfd, 0x9f, 0x01, 0x00, 0x9f, 0xda, 0x90, 0xcc, 0x11, 0x04, 0x00, 0x00,
 }
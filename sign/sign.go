@@ -0,0 +1,109 @@
+package sign
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	privateKeyType = "PRIVATE KEY"
+	publicKeyType  = "PUBLIC KEY"
+)
+
+var (
+	// ErrInvalidKey is returned when a PEM block does not hold an Ed25519 key
+	ErrInvalidKey = errors.New("invalid ed25519 key")
+	// ErrInvalidSignature is returned when results do not match their signature
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// LoadPrivateKey reads a PKCS#8 PEM encoded Ed25519 private key from path
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != privateKeyType {
+		return nil, ErrInvalidKey
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+	return priv, nil
+}
+
+// MarshalPublicKey PEM encodes a public key as PKIX
+func MarshalPublicKey(pub ed25519.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: publicKeyType, Bytes: der}), nil
+}
+
+// ParsePublicKey decodes a PEM encoded PKIX Ed25519 public key
+func ParsePublicKey(data []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != publicKeyType {
+		return nil, ErrInvalidKey
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+	return pub, nil
+}
+
+// Results signs the deterministic proto encoding of the results
+func Results(key ed25519.PrivateKey, results *pb.Results) (*pb.SignedResults, error) {
+	msg, err := encode(results)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SignedResults{
+		Results:   results,
+		Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(key, msg)),
+	}, nil
+}
+
+// Verify checks the signature of signed results against the public key
+func Verify(pub ed25519.PublicKey, signed *pb.SignedResults) error {
+	sig, err := base64.StdEncoding.DecodeString(signed.GetSignature())
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	msg, err := encode(signed.GetResults())
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(pub, msg, sig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func encode(results *pb.Results) ([]byte, error) {
+	b := proto.NewBuffer(nil)
+	b.SetDeterministic(true)
+	if err := b.Marshal(results); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
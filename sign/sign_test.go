@@ -0,0 +1,100 @@
+package sign
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeKey(t *testing.T, block *pem.Block) string {
+	f, err := ioutil.TempFile("", "key")
+	assert.Nil(t, err)
+	defer f.Close()
+	assert.Nil(t, pem.Encode(f, block))
+	return f.Name()
+}
+
+func Test_LoadPrivateKey(t *testing.T) {
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	der, _ := x509.MarshalPKCS8PrivateKey(priv)
+
+	valid := writeKey(t, &pem.Block{Type: privateKeyType, Bytes: der})
+	wrongType := writeKey(t, &pem.Block{Type: publicKeyType, Bytes: der})
+	garbage := writeKey(t, &pem.Block{Type: privateKeyType, Bytes: []byte("garbage")})
+	defer os.Remove(valid)
+	defer os.Remove(wrongType)
+	defer os.Remove(garbage)
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{"Valid key", valid, false},
+		{"Wrong block type", wrongType, true},
+		{"Invalid der", garbage, true},
+		{"Missing file", "/nonexistent/key.pem", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := LoadPrivateKey(tt.path)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, priv, key)
+		})
+	}
+}
+
+func Test_PublicKey(t *testing.T) {
+	pub, _, _ := ed25519.GenerateKey(rand.Reader)
+	data, err := MarshalPublicKey(pub)
+	assert.Nil(t, err)
+
+	parsed, err := ParsePublicKey(data)
+	assert.Nil(t, err)
+	assert.Equal(t, pub, parsed)
+
+	_, err = ParsePublicKey([]byte("not a key"))
+	assert.Equal(t, ErrInvalidKey, err)
+}
+
+func Test_Verify(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+
+	results := &pb.Results{ElectionId: 1, Ballots: 3, Tallies: []*pb.Tally{{Candidate: "a", Votes: 2}, {Candidate: "b", Votes: 1}}}
+	signed, err := Results(priv, results)
+	assert.Nil(t, err)
+
+	tampered := &pb.SignedResults{
+		Results:   &pb.Results{ElectionId: 1, Ballots: 3, Tallies: []*pb.Tally{{Candidate: "b", Votes: 2}, {Candidate: "a", Votes: 1}}},
+		Signature: signed.GetSignature(),
+	}
+
+	tests := []struct {
+		name    string
+		pub     ed25519.PublicKey
+		signed  *pb.SignedResults
+		wantErr error
+	}{
+		{"Valid signature", pub, signed, nil},
+		{"Other key", otherPub, signed, ErrInvalidSignature},
+		{"Tampered results", pub, tampered, ErrInvalidSignature},
+		{"Invalid encoding", pub, &pb.SignedResults{Results: results, Signature: "%%"}, ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, Verify(tt.pub, tt.signed))
+		})
+	}
+}
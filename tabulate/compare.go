@@ -0,0 +1,97 @@
+package tabulate
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ednesic/vote-test/pb"
+)
+
+// Borda gives each candidate on a ranked ballot one point for every
+// candidate of the election ranked below it, unranked candidates get none
+func Borda(votes []pb.Vote, candidates []string, order Order) []*pb.Tally {
+	points := zero(candidates)
+	for _, v := range votes {
+		for r, c := range v.GetRankings() {
+			points[c] += int64(len(candidates)-1-r) * Weight(&v)
+		}
+	}
+	return Tallies(points, order)
+}
+
+// Compare counts the same ranked ballots by plurality of first preferences,
+// instant-runoff, Borda, Schulze and approval of the top k preferences, all
+// breaking ties by the tie-break policy of the election
+func Compare(election *pb.Election, votes []pb.Vote, k int) *pb.Analysis {
+	ballots := len(votes)
+	votes, candidates, _, _ := resolve(election, votes)
+	votes, candidates, _ = withdraw(election, votes, candidates)
+	order := TieBreak(election, votes, candidates)
+
+	first := make([]pb.Vote, len(votes))
+	top := make([]pb.Vote, len(votes))
+	for i, v := range votes {
+		first[i].Weight, top[i].Weight = v.GetWeight(), v.GetWeight()
+		if len(v.GetRankings()) > 0 {
+			first[i].Candidate = v.GetRankings()[0]
+		}
+		top[i].Selections = v.GetRankings()
+		if len(top[i].Selections) > k {
+			top[i].Selections = top[i].Selections[:k]
+		}
+	}
+
+	irv := InstantRunoff(votes, candidates, order)
+	analysis := &pb.Analysis{
+		Ballots: int64(ballots),
+		Outcomes: []*pb.Outcome{
+			outcome("plurality", Plurality(first, candidates, order), nil),
+			outcome("irv", irv.GetTallies(), eliminated(irv.GetRounds())),
+			outcome("borda", Borda(votes, candidates, order), nil),
+			outcome("schulze", Schulze(votes, candidates, order).GetTallies(), nil),
+			outcome(fmt.Sprintf("approval-top-%d", k), Approval(top, candidates, order), nil),
+		},
+	}
+
+	winners := make(map[string][]string)
+	for _, o := range analysis.Outcomes {
+		winners[o.GetWinner()] = append(winners[o.GetWinner()], o.GetMethod())
+	}
+	for c, methods := range winners {
+		analysis.Winners = append(analysis.Winners, &pb.Agreement{Candidate: c, Methods: methods})
+	}
+	sort.Slice(analysis.Winners, func(i, j int) bool {
+		if len(analysis.Winners[i].Methods) != len(analysis.Winners[j].Methods) {
+			return len(analysis.Winners[i].Methods) > len(analysis.Winners[j].Methods)
+		}
+		return analysis.Winners[i].GetCandidate() < analysis.Winners[j].GetCandidate()
+	})
+	analysis.Disagree = len(analysis.Winners) > 1
+	return analysis
+}
+
+// outcome orders the candidates by their tallies followed by the candidates
+// in out, the first candidate wins
+func outcome(method string, tallies []*pb.Tally, out []string) *pb.Outcome {
+	o := &pb.Outcome{Method: method, Tallies: tallies}
+	for _, t := range tallies {
+		o.Ordering = append(o.Ordering, t.GetCandidate())
+	}
+	o.Ordering = append(o.Ordering, out...)
+	if len(o.Ordering) > 0 {
+		o.Winner = o.Ordering[0]
+	}
+	return o
+}
+
+// eliminated returns the candidates eliminated by instant-runoff, the last
+// eliminated first
+func eliminated(rounds []*pb.Round) []string {
+	var out []string
+	for i := len(rounds) - 1; i >= 0; i-- {
+		if rounds[i].GetEliminated() != "" {
+			out = append(out, rounds[i].GetEliminated())
+		}
+	}
+	return out
+}
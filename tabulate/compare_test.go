@@ -0,0 +1,72 @@
+package tabulate
+
+import (
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Borda(t *testing.T) {
+	votes := []pb.Vote{{Rankings: []string{"a", "b", "c"}}, {Rankings: []string{"b"}, Weight: 2}}
+	assert.Equal(t, []*pb.Tally{{Candidate: "b", Votes: 5}, {Candidate: "a", Votes: 2}, {Candidate: "c"}}, Borda(votes, []string{"a", "b", "c"}, nil))
+}
+
+func Test_Compare(t *testing.T) {
+	var votes []pb.Vote
+	votes = append(votes, ranked(5, "a", "c", "b", "e", "d")...)
+	votes = append(votes, ranked(5, "a", "d", "e", "c", "b")...)
+	votes = append(votes, ranked(8, "b", "e", "d", "a", "c")...)
+	votes = append(votes, ranked(3, "c", "a", "b", "e", "d")...)
+	votes = append(votes, ranked(7, "c", "a", "e", "b", "d")...)
+	votes = append(votes, ranked(2, "c", "b", "a", "d", "e")...)
+	votes = append(votes, ranked(7, "d", "c", "e", "b", "a")...)
+	votes = append(votes, ranked(8, "e", "b", "a", "d", "c")...)
+
+	type outcome struct {
+		method   string
+		ordering []string
+	}
+	tests := []struct {
+		name       string
+		votes      []pb.Vote
+		candidates []string
+		outcomes   []outcome
+		winners    []*pb.Agreement
+		disagree   bool
+	}{
+		{"Methods disagree", votes, []string{"a", "b", "c", "d", "e"}, []outcome{
+			{"plurality", []string{"c", "a", "b", "e", "d"}},
+			{"irv", []string{"c", "a", "b", "e", "d"}},
+			{"borda", []string{"e", "a", "b", "c", "d"}},
+			{"schulze", []string{"e", "a", "c", "b", "d"}},
+			{"approval-top-2", []string{"c", "a", "b", "e", "d"}},
+		}, []*pb.Agreement{
+			{Candidate: "c", Methods: []string{"plurality", "irv", "approval-top-2"}},
+			{Candidate: "e", Methods: []string{"borda", "schulze"}},
+		}, true},
+		{"Methods agree", ranked(3, "a", "b"), []string{"a", "b"}, []outcome{
+			{"plurality", []string{"a", "b"}},
+			{"irv", []string{"a", "b"}},
+			{"borda", []string{"a", "b"}},
+			{"schulze", []string{"a", "b"}},
+			{"approval-top-2", []string{"a", "b"}},
+		}, []*pb.Agreement{
+			{Candidate: "a", Methods: []string{"plurality", "irv", "borda", "schulze", "approval-top-2"}},
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := Compare(&pb.Election{Candidates: tt.candidates}, tt.votes, 2)
+			assert.Equal(t, int64(len(tt.votes)), analysis.GetBallots())
+			assert.Len(t, analysis.GetOutcomes(), len(tt.outcomes))
+			for i, o := range analysis.GetOutcomes() {
+				assert.Equal(t, tt.outcomes[i].method, o.GetMethod())
+				assert.Equal(t, tt.outcomes[i].ordering, o.GetOrdering())
+				assert.Equal(t, tt.outcomes[i].ordering[0], o.GetWinner())
+			}
+			assert.Equal(t, tt.winners, analysis.GetWinners())
+			assert.Equal(t, tt.disagree, analysis.GetDisagree())
+		})
+	}
+}
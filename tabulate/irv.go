@@ -0,0 +1,107 @@
+package tabulate
+
+import (
+	"sort"
+
+	"github.com/ednesic/vote-test/pb"
+)
+
+// InstantRunoff counts ranked ballots in rounds. Every round each ballot
+// counts for its most preferred candidate still running, the last candidate
+// is eliminated and its ballots transferred until one candidate holds a
+// majority of the ballot weight that is not exhausted. Ties for last place
+// eliminate the candidate last in the tie-break order. Without an order the
+// count stops at a tie for last place, declaring the tie, unless the tied
+// candidates hold no ballots to transfer.
+func InstantRunoff(votes []pb.Vote, candidates []string, order Order) *pb.Results {
+	running := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		running[c] = true
+	}
+
+	// holder is the candidate each ballot currently counts for, empty once
+	// the ballot is exhausted
+	var total int64
+	holder := make([]string, len(votes))
+	for i := range votes {
+		holder[i] = next(votes[i].GetRankings(), running)
+		total += Weight(&votes[i])
+	}
+
+	var rounds []*pb.Round
+	for {
+		counts := make(map[string]int64, len(running))
+		for c := range running {
+			counts[c] = 0
+		}
+		var exhausted int64
+		for i, h := range holder {
+			if h == "" {
+				exhausted += Weight(&votes[i])
+				continue
+			}
+			counts[h] += Weight(&votes[i])
+		}
+
+		round := &pb.Round{
+			Number:    int32(len(rounds) + 1),
+			Counts:    Tallies(counts, order),
+			Exhausted: exhausted,
+		}
+		rounds = append(rounds, round)
+
+		continuing := total - exhausted
+		if len(round.Counts) <= 1 || round.Counts[0].GetVotes()*2 > continuing ||
+			(order == nil && lastTied(round.Counts)) {
+			return &pb.Results{
+				Ballots: int64(len(votes)),
+				Tallies: round.Counts,
+				Rounds:  rounds,
+			}
+		}
+
+		loser := round.Counts[len(round.Counts)-1].GetCandidate()
+		round.Eliminated = loser
+		delete(running, loser)
+
+		moved := make(map[string]int64)
+		for i, h := range holder {
+			if h != loser {
+				continue
+			}
+			holder[i] = next(votes[i].GetRankings(), running)
+			moved[holder[i]] += Weight(&votes[i])
+		}
+		round.Transfers = transfers(loser, moved)
+	}
+}
+
+// lastTied tells whether candidates holding ballots tie for last place
+func lastTied(counts []*pb.Tally) bool {
+	last := counts[len(counts)-1].GetVotes()
+	return last > 0 && counts[len(counts)-2].GetVotes() == last
+}
+
+// next returns the most preferred candidate still running
+func next(rankings []string, running map[string]bool) string {
+	for _, c := range rankings {
+		if running[c] {
+			return c
+		}
+	}
+	return ""
+}
+
+func transfers(from string, moved map[string]int64) []*pb.Transfer {
+	ts := make([]*pb.Transfer, 0, len(moved))
+	for to, n := range moved {
+		ts = append(ts, &pb.Transfer{From: from, To: to, Votes: n})
+	}
+	sort.Slice(ts, func(i, j int) bool {
+		if ts[i].GetVotes() != ts[j].GetVotes() {
+			return ts[i].GetVotes() > ts[j].GetVotes()
+		}
+		return ts[i].GetTo() < ts[j].GetTo()
+	})
+	return ts
+}
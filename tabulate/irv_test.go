@@ -0,0 +1,99 @@
+package tabulate
+
+import (
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func ranked(n int, rankings ...string) []pb.Vote {
+	votes := make([]pb.Vote, n)
+	for i := range votes {
+		votes[i].Rankings = rankings
+	}
+	return votes
+}
+
+func Test_InstantRunoff(t *testing.T) {
+	var votes []pb.Vote
+	votes = append(votes, ranked(4, "a", "b")...)
+	votes = append(votes, ranked(3, "b", "c")...)
+	votes = append(votes, ranked(2, "c", "b")...)
+	votes = append(votes, ranked(1, "d")...)
+
+	tests := []struct {
+		name       string
+		votes      []pb.Vote
+		candidates []string
+		order      Order
+		want       *pb.Results
+	}{
+		{"Majority on first round", ranked(2, "a"), []string{"a", "b"}, nil, &pb.Results{
+			Ballots: 2,
+			Tallies: []*pb.Tally{{Candidate: "a", Votes: 2}, {Candidate: "b"}},
+			Rounds: []*pb.Round{
+				{Number: 1, Counts: []*pb.Tally{{Candidate: "a", Votes: 2}, {Candidate: "b"}}},
+			},
+		}},
+		{"Transfers until majority", votes, []string{"a", "b", "c", "d"}, nil, &pb.Results{
+			Ballots: 10,
+			Tallies: []*pb.Tally{{Candidate: "b", Votes: 5}, {Candidate: "a", Votes: 4}},
+			Rounds: []*pb.Round{
+				{Number: 1, Counts: []*pb.Tally{{Candidate: "a", Votes: 4}, {Candidate: "b", Votes: 3}, {Candidate: "c", Votes: 2}, {Candidate: "d", Votes: 1}},
+					Eliminated: "d", Transfers: []*pb.Transfer{{From: "d", To: "", Votes: 1}}},
+				{Number: 2, Counts: []*pb.Tally{{Candidate: "a", Votes: 4}, {Candidate: "b", Votes: 3}, {Candidate: "c", Votes: 2}}, Exhausted: 1,
+					Eliminated: "c", Transfers: []*pb.Transfer{{From: "c", To: "b", Votes: 2}}},
+				{Number: 3, Counts: []*pb.Tally{{Candidate: "b", Votes: 5}, {Candidate: "a", Votes: 4}}, Exhausted: 1},
+			},
+		}},
+		{"Tie for last eliminates last in tie-break order", []pb.Vote{{Rankings: []string{"a"}}, {Rankings: []string{"b"}}}, []string{"a", "b"}, Order{"a": 0, "b": 1}, &pb.Results{
+			Ballots: 2,
+			Tallies: []*pb.Tally{{Candidate: "a", Votes: 1}},
+			Rounds: []*pb.Round{
+				{Number: 1, Counts: []*pb.Tally{{Candidate: "a", Votes: 1}, {Candidate: "b", Votes: 1}},
+					Eliminated: "b", Transfers: []*pb.Transfer{{From: "b", To: "", Votes: 1}}},
+				{Number: 2, Counts: []*pb.Tally{{Candidate: "a", Votes: 1}}, Exhausted: 1},
+			},
+		}},
+		{"Tie without order is declared", []pb.Vote{{Rankings: []string{"a"}}, {Rankings: []string{"b"}}}, []string{"a", "b"}, nil, &pb.Results{
+			Ballots: 2,
+			Tallies: []*pb.Tally{{Candidate: "a", Votes: 1}, {Candidate: "b", Votes: 1}},
+			Rounds:  []*pb.Round{{Number: 1, Counts: []*pb.Tally{{Candidate: "a", Votes: 1}, {Candidate: "b", Votes: 1}}}},
+		}},
+		{"Tie for last without order is declared", append(ranked(3, "a"), append(ranked(2, "b"), ranked(2, "c")...)...), []string{"a", "b", "c"}, nil, &pb.Results{
+			Ballots: 7,
+			Tallies: []*pb.Tally{{Candidate: "a", Votes: 3}, {Candidate: "b", Votes: 2}, {Candidate: "c", Votes: 2}},
+			Rounds:  []*pb.Round{{Number: 1, Counts: []*pb.Tally{{Candidate: "a", Votes: 3}, {Candidate: "b", Votes: 2}, {Candidate: "c", Votes: 2}}}},
+		}},
+		{"Tie for last without ballots eliminates", []pb.Vote{{Rankings: []string{"a"}}, {Rankings: []string{"b"}}}, []string{"a", "b", "c", "d"}, nil, &pb.Results{
+			Ballots: 2,
+			Tallies: []*pb.Tally{{Candidate: "a", Votes: 1}, {Candidate: "b", Votes: 1}},
+			Rounds: []*pb.Round{
+				{Number: 1, Counts: []*pb.Tally{{Candidate: "a", Votes: 1}, {Candidate: "b", Votes: 1}, {Candidate: "c"}, {Candidate: "d"}},
+					Eliminated: "d", Transfers: []*pb.Transfer{}},
+				{Number: 2, Counts: []*pb.Tally{{Candidate: "a", Votes: 1}, {Candidate: "b", Votes: 1}, {Candidate: "c"}},
+					Eliminated: "c", Transfers: []*pb.Transfer{}},
+				{Number: 3, Counts: []*pb.Tally{{Candidate: "a", Votes: 1}, {Candidate: "b", Votes: 1}}},
+			},
+		}},
+		{"Weighted ballots", []pb.Vote{{Rankings: []string{"a"}, Weight: 3}, {Rankings: []string{"b"}}, {Rankings: []string{"c", "b"}, Weight: 2}}, []string{"a", "b", "c"}, nil, &pb.Results{
+			Ballots: 3,
+			Tallies: []*pb.Tally{{Candidate: "a", Votes: 3}, {Candidate: "c", Votes: 2}},
+			Rounds: []*pb.Round{
+				{Number: 1, Counts: []*pb.Tally{{Candidate: "a", Votes: 3}, {Candidate: "c", Votes: 2}, {Candidate: "b", Votes: 1}},
+					Eliminated: "b", Transfers: []*pb.Transfer{{From: "b", To: "", Votes: 1}}},
+				{Number: 2, Counts: []*pb.Tally{{Candidate: "a", Votes: 3}, {Candidate: "c", Votes: 2}}, Exhausted: 1},
+			},
+		}},
+		{"No ballots", nil, []string{"a"}, nil, &pb.Results{
+			Tallies: []*pb.Tally{{Candidate: "a"}},
+			Rounds:  []*pb.Round{{Number: 1, Counts: []*pb.Tally{{Candidate: "a"}}}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, InstantRunoff(tt.votes, tt.candidates, tt.order))
+		})
+	}
+}
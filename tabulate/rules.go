@@ -0,0 +1,64 @@
+package tabulate
+
+import (
+	"github.com/ednesic/vote-test/pb"
+)
+
+// Judge applies the election rules to the results of ballots weighing
+// turnout, blank of them on blank ballots. Only methods counting each ballot
+// once for a candidate, plurality, approval and the final instant-runoff
+// round, have a leader with a share of the ballots; the others pass once
+// valid. A tie for the lead leaves no leader, so nothing passes, unless the
+// tie-break policy of the results decided it.
+func Judge(method pb.VotingMethod, rules *pb.Rules, results *pb.Results, turnout, blank int64) *pb.Verdict {
+	verdict := &pb.Verdict{
+		Turnout: turnout,
+		Valid:   turnout >= rules.GetQuorum() && float64(turnout)*100 >= rules.GetQuorumPercent()*float64(rules.GetEligible()),
+	}
+	if !countsBallots(method) {
+		verdict.Passed = verdict.Valid
+		return verdict
+	}
+
+	tallies := results.GetTallies()
+	declared := results.GetTie().GetPolicy() == pb.TieBreak_DECLARE
+	if len(tallies) == 0 || (declared && len(tallies) > 1 && tallies[0].GetVotes() == tallies[1].GetVotes()) {
+		return verdict
+	}
+	verdict.Leader = tallies[0].GetCandidate()
+
+	base := turnout
+	if !rules.GetCountAbstentions() {
+		base -= blank
+	}
+	if base > 0 {
+		verdict.Share = float64(tallies[0].GetVotes()) / float64(base)
+	}
+
+	reached := verdict.Share >= rules.GetMajority()
+	if rules.GetStrict() {
+		reached = verdict.Share > rules.GetMajority()
+	}
+	verdict.Passed = verdict.Valid && reached
+	return verdict
+}
+
+func countsBallots(method pb.VotingMethod) bool {
+	switch method {
+	case pb.VotingMethod_PLURALITY, pb.VotingMethod_APPROVAL, pb.VotingMethod_RANKED:
+		return true
+	}
+	return false
+}
+
+// turnout returns the weight of all ballots and of the blank ones
+func turnout(votes []pb.Vote) (int64, int64) {
+	var total, blank int64
+	for i := range votes {
+		total += Weight(&votes[i])
+		if votes[i].GetAbstain() {
+			blank += Weight(&votes[i])
+		}
+	}
+	return total, blank
+}
@@ -0,0 +1,51 @@
+package tabulate
+
+import (
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Judge(t *testing.T) {
+	results := &pb.Results{
+		Tallies: []*pb.Tally{{Candidate: "yes", Votes: 6}, {Candidate: "no", Votes: 3}},
+	}
+
+	tests := []struct {
+		name    string
+		method  pb.VotingMethod
+		rules   *pb.Rules
+		results *pb.Results
+		turnout int64
+		want    *pb.Verdict
+	}{
+		{"No rules", pb.VotingMethod_PLURALITY, &pb.Rules{}, results, 10, &pb.Verdict{Valid: true, Passed: true, Turnout: 10, Leader: "yes", Share: 6.0 / 9}},
+		{"Quorum reached", pb.VotingMethod_PLURALITY, &pb.Rules{Quorum: 10}, results, 10, &pb.Verdict{Valid: true, Passed: true, Turnout: 10, Leader: "yes", Share: 6.0 / 9}},
+		{"Quorum missed", pb.VotingMethod_PLURALITY, &pb.Rules{Quorum: 11}, results, 10, &pb.Verdict{Turnout: 10, Leader: "yes", Share: 6.0 / 9}},
+		{"Quorum percent reached", pb.VotingMethod_PLURALITY, &pb.Rules{QuorumPercent: 50, Eligible: 20}, results, 10, &pb.Verdict{Valid: true, Passed: true, Turnout: 10, Leader: "yes", Share: 6.0 / 9}},
+		{"Quorum percent missed", pb.VotingMethod_PLURALITY, &pb.Rules{QuorumPercent: 50, Eligible: 21}, results, 10, &pb.Verdict{Turnout: 10, Leader: "yes", Share: 6.0 / 9}},
+		{"Two thirds without abstentions", pb.VotingMethod_PLURALITY, &pb.Rules{Majority: 2.0 / 3}, results, 10, &pb.Verdict{Valid: true, Passed: true, Turnout: 10, Leader: "yes", Share: 6.0 / 9}},
+		{"Two thirds strict", pb.VotingMethod_PLURALITY, &pb.Rules{Majority: 2.0 / 3, Strict: true}, results, 10, &pb.Verdict{Valid: true, Turnout: 10, Leader: "yes", Share: 6.0 / 9}},
+		{"Two thirds counting abstentions", pb.VotingMethod_PLURALITY, &pb.Rules{Majority: 2.0 / 3, CountAbstentions: true}, results, 10, &pb.Verdict{Valid: true, Turnout: 10, Leader: "yes", Share: 0.6}},
+		{"Final instant-runoff round", pb.VotingMethod_RANKED, &pb.Rules{Majority: 0.5}, results, 10, &pb.Verdict{Valid: true, Passed: true, Turnout: 10, Leader: "yes", Share: 6.0 / 9}},
+		{"Schulze has no share", pb.VotingMethod_SCHULZE, &pb.Rules{Quorum: 10}, results, 10, &pb.Verdict{Valid: true, Passed: true, Turnout: 10}},
+		{"Score has no share", pb.VotingMethod_SCORE, &pb.Rules{Quorum: 10}, results, 10, &pb.Verdict{Valid: true, Passed: true, Turnout: 10}},
+		{"STV has no share", pb.VotingMethod_STV, &pb.Rules{Quorum: 11}, results, 10, &pb.Verdict{Turnout: 10}},
+		{"Tie has no leader", pb.VotingMethod_PLURALITY, &pb.Rules{}, &pb.Results{Tallies: []*pb.Tally{{Candidate: "a", Votes: 1}, {Candidate: "b", Votes: 1}}}, 2, &pb.Verdict{Valid: true, Turnout: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Judge(tt.method, tt.rules, tt.results, tt.turnout, 1))
+		})
+	}
+}
+
+func Test_Count_rules(t *testing.T) {
+	election := &pb.Election{Candidates: []string{"yes", "no"}, Abstain: true, Rules: &pb.Rules{Quorum: 3, Majority: 0.5, Strict: true}}
+	votes := []pb.Vote{{Candidate: "yes", Weight: 2}, {Candidate: "no"}, {Abstain: true, Weight: 3}}
+
+	results := Count(election, votes)
+	assert.Equal(t, int64(1), results.GetAbstentions())
+	assert.Equal(t, &pb.Verdict{Valid: true, Passed: true, Turnout: 6, Leader: "yes", Share: 2.0 / 3}, results.GetVerdict())
+}
@@ -0,0 +1,104 @@
+package tabulate
+
+import (
+	"github.com/ednesic/vote-test/pb"
+)
+
+// Schulze counts ranked ballots pairwise with their weight. A ballot prefers a
+// candidate over every candidate ranked below it and over every candidate it
+// leaves out.
+// The tallies order the candidates by the Schulze ranking, with the number
+// of candidates each one beats along the strongest paths as votes.
+func Schulze(votes []pb.Vote, candidates []string, order Order) *pb.Results {
+	n := len(candidates)
+	index := make(map[string]int, n)
+	for i, c := range candidates {
+		index[c] = i
+	}
+
+	d := matrix(n)
+	for _, v := range votes {
+		w := Weight(&v)
+		// unranked candidates share the place after the last ranked one
+		place := make([]int, n)
+		for i := range place {
+			place[i] = len(v.GetRankings())
+		}
+		for r, c := range v.GetRankings() {
+			if i, ok := index[c]; ok {
+				place[i] = r
+			}
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if place[i] < place[j] {
+					d[i][j] += w
+				}
+			}
+		}
+	}
+
+	p := matrix(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j && d[i][j] > d[j][i] {
+				p[i][j] = d[i][j]
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == j {
+				continue
+			}
+			for k := 0; k < n; k++ {
+				if i == k || j == k {
+					continue
+				}
+				through := p[j][i]
+				if p[i][k] < through {
+					through = p[i][k]
+				}
+				if through > p[j][k] {
+					p[j][k] = through
+				}
+			}
+		}
+	}
+
+	wins := make(map[string]int64, n)
+	for i, c := range candidates {
+		wins[c] = 0
+		for j := 0; j < n; j++ {
+			if p[i][j] > p[j][i] {
+				wins[c]++
+			}
+		}
+	}
+
+	return &pb.Results{
+		Ballots: int64(len(votes)),
+		Tallies: Tallies(wins, order),
+		Pairwise: &pb.Pairwise{
+			Candidates:  candidates,
+			Preferences: rows(d),
+			Strongest:   rows(p),
+		},
+	}
+}
+
+func matrix(n int) [][]int64 {
+	m := make([][]int64, n)
+	for i := range m {
+		m[i] = make([]int64, n)
+	}
+	return m
+}
+
+func rows(m [][]int64) []*pb.Row {
+	r := make([]*pb.Row, len(m))
+	for i := range m {
+		r[i] = &pb.Row{Counts: m[i]}
+	}
+	return r
+}
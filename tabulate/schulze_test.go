@@ -0,0 +1,63 @@
+package tabulate
+
+import (
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Schulze(t *testing.T) {
+	var votes []pb.Vote
+	votes = append(votes, ranked(5, "a", "c", "b", "e", "d")...)
+	votes = append(votes, ranked(5, "a", "d", "e", "c", "b")...)
+	votes = append(votes, ranked(8, "b", "e", "d", "a", "c")...)
+	votes = append(votes, ranked(3, "c", "a", "b", "e", "d")...)
+	votes = append(votes, ranked(7, "c", "a", "e", "b", "d")...)
+	votes = append(votes, ranked(2, "c", "b", "a", "d", "e")...)
+	votes = append(votes, ranked(7, "d", "c", "e", "b", "a")...)
+	votes = append(votes, ranked(8, "e", "b", "a", "d", "c")...)
+
+	tests := []struct {
+		name       string
+		votes      []pb.Vote
+		candidates []string
+		want       *pb.Results
+	}{
+		{"Strongest paths", votes, []string{"a", "b", "c", "d", "e"}, &pb.Results{
+			Ballots: 45,
+			Tallies: []*pb.Tally{{Candidate: "e", Votes: 4}, {Candidate: "a", Votes: 3}, {Candidate: "c", Votes: 2}, {Candidate: "b", Votes: 1}, {Candidate: "d"}},
+			Pairwise: &pb.Pairwise{
+				Candidates: []string{"a", "b", "c", "d", "e"},
+				Preferences: []*pb.Row{
+					{Counts: []int64{0, 20, 26, 30, 22}},
+					{Counts: []int64{25, 0, 16, 33, 18}},
+					{Counts: []int64{19, 29, 0, 17, 24}},
+					{Counts: []int64{15, 12, 28, 0, 14}},
+					{Counts: []int64{23, 27, 21, 31, 0}},
+				},
+				Strongest: []*pb.Row{
+					{Counts: []int64{0, 28, 28, 30, 24}},
+					{Counts: []int64{25, 0, 28, 33, 24}},
+					{Counts: []int64{25, 29, 0, 29, 24}},
+					{Counts: []int64{25, 28, 28, 0, 24}},
+					{Counts: []int64{25, 28, 28, 31, 0}},
+				},
+			},
+		}},
+		{"Unranked candidates come last", []pb.Vote{{Rankings: []string{"b"}}}, []string{"a", "b"}, &pb.Results{
+			Ballots: 1,
+			Tallies: []*pb.Tally{{Candidate: "b", Votes: 1}, {Candidate: "a"}},
+			Pairwise: &pb.Pairwise{
+				Candidates:  []string{"a", "b"},
+				Preferences: []*pb.Row{{Counts: []int64{0, 0}}, {Counts: []int64{1, 0}}},
+				Strongest:   []*pb.Row{{Counts: []int64{0, 0}}, {Counts: []int64{1, 0}}},
+			},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Schulze(tt.votes, tt.candidates, nil))
+		})
+	}
+}
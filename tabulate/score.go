@@ -0,0 +1,72 @@
+package tabulate
+
+import (
+	"github.com/ednesic/vote-test/pb"
+)
+
+// Score sums the scores given to each candidate times the ballot weight, with
+// the weighted distribution of scores from 0 to max for every candidate
+func Score(votes []pb.Vote, candidates []string, max int, order Order) *pb.Results {
+	sums := zero(candidates)
+	distributions := make([]*pb.Distribution, len(candidates))
+	for i, c := range candidates {
+		distributions[i] = &pb.Distribution{Candidate: c, Counts: make([]int64, max+1)}
+		for _, v := range votes {
+			score := int(v.GetScores()[c])
+			sums[c] += int64(score) * Weight(&v)
+			if score >= 0 && score <= max {
+				distributions[i].Counts[score] += Weight(&v)
+			}
+		}
+	}
+
+	return &pb.Results{
+		Ballots:       int64(len(votes)),
+		Tallies:       Tallies(sums, order),
+		Distributions: distributions,
+	}
+}
+
+// STAR sums the scores like Score and runs off the two highest scored
+// candidates. The finalist preferred by more ballot weight wins, ties go to
+// the finalist with the higher score and then to the tie-break order.
+// Without an order a tie on both is declared and no one is elected.
+func STAR(votes []pb.Vote, candidates []string, max int, order Order) *pb.Results {
+	results := Score(votes, candidates, max, order)
+	if len(results.Tallies) < 2 {
+		for _, t := range results.Tallies {
+			results.Elected = append(results.Elected, t.GetCandidate())
+		}
+		return results
+	}
+
+	first, second := results.Tallies[0].GetCandidate(), results.Tallies[1].GetCandidate()
+	runoff := &pb.Runoff{
+		Finalists: []*pb.Tally{{Candidate: first}, {Candidate: second}},
+	}
+	for _, v := range votes {
+		a, b := v.GetScores()[first], v.GetScores()[second]
+		switch {
+		case a > b:
+			runoff.Finalists[0].Votes += Weight(&v)
+		case b > a:
+			runoff.Finalists[1].Votes += Weight(&v)
+		default:
+			runoff.NoPreference += Weight(&v)
+		}
+	}
+
+	runoff.Winner = first
+	if runoff.Finalists[1].GetVotes() > runoff.Finalists[0].GetVotes() {
+		runoff.Winner = second
+		runoff.Finalists[0], runoff.Finalists[1] = runoff.Finalists[1], runoff.Finalists[0]
+	}
+	results.Runoff = runoff
+	if order == nil && runoff.Finalists[0].GetVotes() == runoff.Finalists[1].GetVotes() &&
+		results.Tallies[0].GetVotes() == results.Tallies[1].GetVotes() {
+		runoff.Winner = ""
+		return results
+	}
+	results.Elected = []string{runoff.Winner}
+	return results
+}
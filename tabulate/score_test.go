@@ -0,0 +1,62 @@
+package tabulate
+
+import (
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_STAR(t *testing.T) {
+	votes := []pb.Vote{
+		{Scores: map[string]int32{"a": 3, "b": 0}},
+		{Scores: map[string]int32{"a": 1, "b": 2}},
+		{Scores: map[string]int32{"a": 1, "b": 2}},
+		{Scores: map[string]int32{"a": 1, "b": 1, "c": 1}},
+	}
+	distributions := []*pb.Distribution{
+		{Candidate: "a", Counts: []int64{0, 3, 0, 1}},
+		{Candidate: "b", Counts: []int64{1, 1, 2, 0}},
+		{Candidate: "c", Counts: []int64{3, 1, 0, 0}},
+	}
+
+	tests := []struct {
+		name       string
+		votes      []pb.Vote
+		candidates []string
+		want       *pb.Results
+	}{
+		{"Runoff overturns the highest score", votes, []string{"a", "b", "c"}, &pb.Results{
+			Ballots:       4,
+			Tallies:       []*pb.Tally{{Candidate: "a", Votes: 6}, {Candidate: "b", Votes: 5}, {Candidate: "c", Votes: 1}},
+			Distributions: distributions,
+			Elected:       []string{"b"},
+			Runoff: &pb.Runoff{
+				Finalists:    []*pb.Tally{{Candidate: "b", Votes: 2}, {Candidate: "a", Votes: 1}},
+				NoPreference: 1,
+				Winner:       "b",
+			},
+		}},
+		{"Tied runoff goes to the highest score", []pb.Vote{{Scores: map[string]int32{"a": 3}}, {Scores: map[string]int32{"b": 1}}}, []string{"a", "b"}, &pb.Results{
+			Ballots:       2,
+			Tallies:       []*pb.Tally{{Candidate: "a", Votes: 3}, {Candidate: "b", Votes: 1}},
+			Distributions: []*pb.Distribution{{Candidate: "a", Counts: []int64{1, 0, 0, 1}}, {Candidate: "b", Counts: []int64{1, 1, 0, 0}}},
+			Elected:       []string{"a"},
+			Runoff: &pb.Runoff{
+				Finalists: []*pb.Tally{{Candidate: "a", Votes: 1}, {Candidate: "b", Votes: 1}},
+				Winner:    "a",
+			},
+		}},
+		{"Single candidate", votes[:1], []string{"a"}, &pb.Results{
+			Ballots:       1,
+			Tallies:       []*pb.Tally{{Candidate: "a", Votes: 3}},
+			Distributions: []*pb.Distribution{{Candidate: "a", Counts: []int64{0, 0, 0, 1}}},
+			Elected:       []string{"a"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, STAR(tt.votes, tt.candidates, 3, nil))
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package tabulate
+
+import (
+	"sort"
+
+	"github.com/ednesic/vote-test/pb"
+)
+
+// STV elects seats candidates from ranked ballots by single transferable vote
+// with the Droop quota. Every ballot starts with the weight of its voter and
+// counts for its most preferred hopeful candidate. Candidates reaching the quota are
+// elected and the ballots they hold carry on with the surplus share of their
+// weight, otherwise the last hopeful is eliminated and its ballots carry on
+// unchanged. Ties for last place eliminate the candidate last in the
+// tie-break order. Without an order the count stops at a tie for last place
+// between candidates holding ballots, declaring the tie.
+func STV(votes []pb.Vote, candidates []string, seats int, order Order) *pb.Results {
+	if seats < 1 {
+		seats = 1
+	}
+
+	hopeful := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		hopeful[c] = true
+	}
+
+	var valid int64
+	holder := make([]string, len(votes))
+	weight := make([]float64, len(votes))
+	for i := range votes {
+		if len(votes[i].GetRankings()) > 0 {
+			valid += Weight(&votes[i])
+		}
+		holder[i] = next(votes[i].GetRankings(), hopeful)
+		weight[i] = float64(Weight(&votes[i]))
+	}
+
+	results := &pb.Results{
+		Ballots: int64(len(votes)),
+		Quota:   float64(valid/int64(seats+1) + 1),
+	}
+
+	for len(results.Elected) < seats && len(hopeful) > 0 {
+		counts := make(map[string]float64, len(hopeful))
+		for c := range hopeful {
+			counts[c] = 0
+		}
+		var exhausted float64
+		for i, h := range holder {
+			if h == "" {
+				exhausted += weight[i]
+				continue
+			}
+			counts[h] += weight[i]
+		}
+
+		round := &pb.StvRound{
+			Number:    int32(len(results.StvRounds) + 1),
+			Counts:    standings(counts, order),
+			Exhausted: exhausted,
+		}
+		results.StvRounds = append(results.StvRounds, round)
+		if round.Number == 1 {
+			first := make(map[string]int64, len(counts))
+			for c, n := range counts {
+				first[c] = int64(n)
+			}
+			results.Tallies = Tallies(first, order)
+		}
+
+		// the remaining hopefuls fill the remaining seats
+		if len(results.Elected)+len(hopeful) <= seats {
+			for _, w := range round.Counts {
+				round.Elected = append(round.Elected, w.GetCandidate())
+			}
+			results.Elected = append(results.Elected, round.Elected...)
+			break
+		}
+
+		for _, w := range round.Counts {
+			if w.GetVotes() >= results.Quota && len(results.Elected)+len(round.Elected) < seats {
+				round.Elected = append(round.Elected, w.GetCandidate())
+				delete(hopeful, w.GetCandidate())
+			}
+		}
+		if len(round.Elected) > 0 {
+			results.Elected = append(results.Elected, round.Elected...)
+			if len(results.Elected) == seats {
+				break
+			}
+			for _, c := range round.Elected {
+				share := (counts[c] - results.Quota) / counts[c]
+				round.Transfers = append(round.Transfers, transfer(votes, holder, weight, c, share, hopeful)...)
+			}
+			continue
+		}
+
+		last := round.Counts[len(round.Counts)-1].GetVotes()
+		if order == nil && last > 0 && round.Counts[len(round.Counts)-2].GetVotes() == last {
+			break
+		}
+
+		loser := round.Counts[len(round.Counts)-1].GetCandidate()
+		round.Eliminated = loser
+		delete(hopeful, loser)
+		round.Transfers = transfer(votes, holder, weight, loser, 1, hopeful)
+	}
+	return results
+}
+
+// transfer moves the ballots held by from to their next hopeful preference,
+// scaling their weight by share
+func transfer(votes []pb.Vote, holder []string, weight []float64, from string, share float64, hopeful map[string]bool) []*pb.WeightedTransfer {
+	moved := make(map[string]float64)
+	for i, h := range holder {
+		if h != from {
+			continue
+		}
+		weight[i] *= share
+		holder[i] = next(votes[i].GetRankings(), hopeful)
+		moved[holder[i]] += weight[i]
+	}
+
+	ts := make([]*pb.WeightedTransfer, 0, len(moved))
+	for to, w := range moved {
+		if w > 0 {
+			ts = append(ts, &pb.WeightedTransfer{From: from, To: to, Votes: w})
+		}
+	}
+	sort.Slice(ts, func(i, j int) bool {
+		if ts[i].GetVotes() != ts[j].GetVotes() {
+			return ts[i].GetVotes() > ts[j].GetVotes()
+		}
+		return ts[i].GetTo() < ts[j].GetTo()
+	})
+	return ts
+}
+
+// standings orders weighted counts by votes and then by the tie-break order
+func standings(counts map[string]float64, order Order) []*pb.Weighted {
+	ws := make([]*pb.Weighted, 0, len(counts))
+	for c, n := range counts {
+		ws = append(ws, &pb.Weighted{Candidate: c, Votes: n})
+	}
+	sort.Slice(ws, func(i, j int) bool {
+		if ws[i].GetVotes() != ws[j].GetVotes() {
+			return ws[i].GetVotes() > ws[j].GetVotes()
+		}
+		return order.before(ws[i].GetCandidate(), ws[j].GetCandidate())
+	})
+	return ws
+}
@@ -0,0 +1,70 @@
+package tabulate
+
+import (
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_STV(t *testing.T) {
+	var votes []pb.Vote
+	votes = append(votes, ranked(6, "a", "b")...)
+	votes = append(votes, ranked(2, "a", "c")...)
+	votes = append(votes, ranked(3, "c")...)
+	votes = append(votes, ranked(2, "d", "c")...)
+
+	tests := []struct {
+		name       string
+		votes      []pb.Vote
+		candidates []string
+		seats      int
+		want       *pb.Results
+	}{
+		{"Surplus and elimination transfers", votes, []string{"a", "b", "c", "d"}, 2, &pb.Results{
+			Ballots: 13,
+			Tallies: []*pb.Tally{{Candidate: "a", Votes: 8}, {Candidate: "c", Votes: 3}, {Candidate: "d", Votes: 2}, {Candidate: "b"}},
+			Elected: []string{"a", "c"},
+			Quota:   5,
+			StvRounds: []*pb.StvRound{
+				{Number: 1, Counts: []*pb.Weighted{{Candidate: "a", Votes: 8}, {Candidate: "c", Votes: 3}, {Candidate: "d", Votes: 2}, {Candidate: "b"}},
+					Elected: []string{"a"}, Transfers: []*pb.WeightedTransfer{{From: "a", To: "b", Votes: 2.25}, {From: "a", To: "c", Votes: 0.75}}},
+				{Number: 2, Counts: []*pb.Weighted{{Candidate: "c", Votes: 3.75}, {Candidate: "b", Votes: 2.25}, {Candidate: "d", Votes: 2}},
+					Eliminated: "d", Transfers: []*pb.WeightedTransfer{{From: "d", To: "c", Votes: 2}}},
+				{Number: 3, Counts: []*pb.Weighted{{Candidate: "c", Votes: 5.75}, {Candidate: "b", Votes: 2.25}},
+					Elected: []string{"c"}},
+			},
+		}},
+		{"Remaining candidates fill the seats", ranked(1, "a"), []string{"a", "b"}, 2, &pb.Results{
+			Ballots: 1,
+			Tallies: []*pb.Tally{{Candidate: "a", Votes: 1}, {Candidate: "b"}},
+			Elected: []string{"a", "b"},
+			Quota:   1,
+			StvRounds: []*pb.StvRound{
+				{Number: 1, Counts: []*pb.Weighted{{Candidate: "a", Votes: 1}, {Candidate: "b"}}, Elected: []string{"a", "b"}},
+			},
+		}},
+		{"Zero seats elect one", ranked(3, "b", "a"), []string{"a", "b"}, 0, &pb.Results{
+			Ballots: 3,
+			Tallies: []*pb.Tally{{Candidate: "b", Votes: 3}, {Candidate: "a"}},
+			Elected: []string{"b"},
+			Quota:   2,
+			StvRounds: []*pb.StvRound{
+				{Number: 1, Counts: []*pb.Weighted{{Candidate: "b", Votes: 3}, {Candidate: "a"}}, Elected: []string{"b"}},
+			},
+		}},
+		{"Tie for last without order is declared", append(ranked(3, "a"), append(ranked(2, "b"), ranked(2, "c")...)...), []string{"a", "b", "c"}, 1, &pb.Results{
+			Ballots: 7,
+			Tallies: []*pb.Tally{{Candidate: "a", Votes: 3}, {Candidate: "b", Votes: 2}, {Candidate: "c", Votes: 2}},
+			Quota:   4,
+			StvRounds: []*pb.StvRound{
+				{Number: 1, Counts: []*pb.Weighted{{Candidate: "a", Votes: 3}, {Candidate: "b", Votes: 2}, {Candidate: "c", Votes: 2}}},
+			},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, STV(tt.votes, tt.candidates, tt.seats, nil))
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package tabulate
+
+import (
+	"sort"
+
+	"github.com/ednesic/vote-test/ballot"
+	"github.com/ednesic/vote-test/pb"
+)
+
+// Plurality counts the weight of every ballot for its candidate. Every
+// candidate of the election gets a tally, ordered by votes and then by the
+// tie-break order.
+func Plurality(votes []pb.Vote, candidates []string, order Order) []*pb.Tally {
+	counts := zero(candidates)
+	for _, v := range votes {
+		counts[v.GetCandidate()] += Weight(&v)
+	}
+	return Tallies(counts, order)
+}
+
+// Approval counts the weight of a ballot for every candidate it selects
+func Approval(votes []pb.Vote, candidates []string, order Order) []*pb.Tally {
+	counts := zero(candidates)
+	for _, v := range votes {
+		for _, c := range v.GetSelections() {
+			counts[c] += Weight(&v)
+		}
+	}
+	return Tallies(counts, order)
+}
+
+// Count tallies the ballots with the voting method of the election, each
+// contest of a referendum is counted on its own. Blank ballots count in the
+// ballots but for no candidate. Ballots for withdrawn candidates follow the
+// withdrawal policy and ties are broken by the tie-break policy of the
+// election. Elections with rules get a verdict.
+func Count(election *pb.Election, votes []pb.Vote) *pb.Results {
+	if len(election.GetContests()) > 0 {
+		return contests(election, votes)
+	}
+
+	counted, candidates, writeIns, abstentions := resolve(election, votes)
+	counted, candidates, voided := withdraw(election, counted, candidates)
+	order := TieBreak(election, counted, candidates)
+	results := count(election, counted, candidates, order)
+	results.Ballots = int64(len(votes))
+	results.WriteIns = writeIns
+	results.Abstentions = abstentions
+	results.Withdrawn = election.GetWithdrawn()
+	results.Voided = voided
+	results.Tie = Tie(election, order, results)
+	if election.GetRules() != nil {
+		total, blank := turnout(votes)
+		results.Verdict = Judge(election.GetVotingMethod(), election.GetRules(), results, total, blank)
+	}
+	return results
+}
+
+func count(election *pb.Election, votes []pb.Vote, candidates []string, order Order) *pb.Results {
+	var tallies []*pb.Tally
+	switch election.GetVotingMethod() {
+	case pb.VotingMethod_APPROVAL:
+		tallies = Approval(votes, candidates, order)
+	case pb.VotingMethod_RANKED:
+		return InstantRunoff(votes, candidates, order)
+	case pb.VotingMethod_SCHULZE:
+		return Schulze(votes, candidates, order)
+	case pb.VotingMethod_STV:
+		return STV(votes, candidates, int(election.GetSeats()), order)
+	case pb.VotingMethod_SCORE:
+		return Score(votes, candidates, int(election.GetMaxScore()), order)
+	case pb.VotingMethod_STAR:
+		return STAR(votes, candidates, int(election.GetMaxScore()), order)
+	default:
+		tallies = Plurality(votes, candidates, order)
+	}
+	return &pb.Results{Ballots: int64(len(votes)), Tallies: tallies}
+}
+
+// Tallies orders counts by votes and then by the tie-break order
+func Tallies(counts map[string]int64, order Order) []*pb.Tally {
+	tallies := make([]*pb.Tally, 0, len(counts))
+	for c, n := range counts {
+		tallies = append(tallies, &pb.Tally{Candidate: c, Votes: n})
+	}
+	sort.Slice(tallies, func(i, j int) bool {
+		if tallies[i].GetVotes() != tallies[j].GetVotes() {
+			return tallies[i].GetVotes() > tallies[j].GetVotes()
+		}
+		return order.before(tallies[i].GetCandidate(), tallies[j].GetCandidate())
+	})
+	return tallies
+}
+
+// contests counts the answers to each contest of a referendum
+func contests(election *pb.Election, votes []pb.Vote) *pb.Results {
+	answers := make(map[string][]pb.Vote, len(election.GetContests()))
+	for _, v := range votes {
+		for _, a := range v.GetAnswers() {
+			answer := ballot.Answer(a, v.GetWeight())
+			answer.Cast = v.GetCast()
+			answers[a.GetContest()] = append(answers[a.GetContest()], answer)
+		}
+	}
+
+	results := &pb.Results{Ballots: int64(len(votes))}
+	for _, c := range election.GetContests() {
+		contest := ballot.Contest(c)
+		contest.TieBreak, contest.Seed, contest.Lot = election.GetTieBreak(), election.GetSeed(), election.GetLot()
+		contest.Withdrawal = election.GetWithdrawal()
+		r := Count(contest, answers[c.GetId()])
+		if election.GetRules() != nil {
+			total, blank := turnout(answers[c.GetId()])
+			r.Verdict = Judge(contest.GetVotingMethod(), election.GetRules(), r, total, blank)
+		}
+		results.Contests = append(results.Contests, &pb.ContestResults{Contest: c.GetId(), Results: r})
+	}
+	return results
+}
+
+// Weight returns the weight of a ballot, ballots of unweighted elections
+// weigh one
+func Weight(v *pb.Vote) int64 {
+	if v.GetWeight() > 0 {
+		return v.GetWeight()
+	}
+	return 1
+}
+
+func zero(candidates []string) map[string]int64 {
+	counts := make(map[string]int64, len(candidates))
+	for _, c := range candidates {
+		counts[c] = 0
+	}
+	return counts
+}
@@ -0,0 +1,87 @@
+package tabulate
+
+import (
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Plurality(t *testing.T) {
+	type args struct {
+		votes      []pb.Vote
+		candidates []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*pb.Tally
+	}{
+		{"No votes", args{nil, []string{"b", "a"}}, []*pb.Tally{{Candidate: "a"}, {Candidate: "b"}}},
+		{"Count votes", args{[]pb.Vote{{Candidate: "a"}, {Candidate: "b"}, {Candidate: "b"}}, []string{"a", "b", "c"}},
+			[]*pb.Tally{{Candidate: "b", Votes: 2}, {Candidate: "a", Votes: 1}, {Candidate: "c"}}},
+		{"Tie ordered by name", args{[]pb.Vote{{Candidate: "b"}, {Candidate: "a"}}, []string{"b", "a"}},
+			[]*pb.Tally{{Candidate: "a", Votes: 1}, {Candidate: "b", Votes: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Plurality(tt.args.votes, tt.args.candidates, nil))
+		})
+	}
+}
+
+func Test_Count_contests(t *testing.T) {
+	election := &pb.Election{Contests: []*pb.Contest{
+		{Id: "q1", Candidates: []string{"yes", "no"}},
+		{Id: "q2", Candidates: []string{"a", "b"}, VotingMethod: pb.VotingMethod_APPROVAL},
+	}}
+	votes := []pb.Vote{
+		{Answers: []*pb.Answer{{Contest: "q1", Candidate: "yes"}, {Contest: "q2", Selections: []string{"a", "b"}}}},
+		{Answers: []*pb.Answer{{Contest: "q1", Candidate: "no"}}, Weight: 3},
+		{Answers: []*pb.Answer{{Contest: "q2", Selections: []string{"b"}}}},
+	}
+
+	assert.Equal(t, &pb.Results{
+		Ballots: 3,
+		Contests: []*pb.ContestResults{
+			{Contest: "q1", Results: &pb.Results{Ballots: 2, Tallies: []*pb.Tally{{Candidate: "no", Votes: 3}, {Candidate: "yes", Votes: 1}}}},
+			{Contest: "q2", Results: &pb.Results{Ballots: 2, Tallies: []*pb.Tally{{Candidate: "b", Votes: 2}, {Candidate: "a", Votes: 1}}}},
+		},
+	}, Count(election, votes))
+}
+
+func Test_Count(t *testing.T) {
+	candidates := []string{"a", "b", "c"}
+	tests := []struct {
+		name   string
+		method pb.VotingMethod
+		votes  []pb.Vote
+		want   []*pb.Tally
+	}{
+		{"Plurality", pb.VotingMethod_PLURALITY, []pb.Vote{{Candidate: "a"}, {Candidate: "b"}, {Candidate: "a"}},
+			[]*pb.Tally{{Candidate: "a", Votes: 2}, {Candidate: "b", Votes: 1}, {Candidate: "c"}}},
+		{"Approval", pb.VotingMethod_APPROVAL, []pb.Vote{{Selections: []string{"a", "b"}}, {Selections: []string{"b"}}},
+			[]*pb.Tally{{Candidate: "b", Votes: 2}, {Candidate: "a", Votes: 1}, {Candidate: "c"}}},
+		{"Ranked", pb.VotingMethod_RANKED, []pb.Vote{{Rankings: []string{"c", "a"}}, {Rankings: []string{"c"}}, {Rankings: []string{"a", "c"}}},
+			[]*pb.Tally{{Candidate: "c", Votes: 2}, {Candidate: "a", Votes: 1}, {Candidate: "b"}}},
+		{"Weighted plurality", pb.VotingMethod_PLURALITY, []pb.Vote{{Candidate: "a", Weight: 2}, {Candidate: "b", Weight: 5}, {Candidate: "a"}},
+			[]*pb.Tally{{Candidate: "b", Votes: 5}, {Candidate: "a", Votes: 3}, {Candidate: "c"}}},
+		{"Weighted approval", pb.VotingMethod_APPROVAL, []pb.Vote{{Selections: []string{"a", "b"}, Weight: 3}, {Selections: []string{"b"}}},
+			[]*pb.Tally{{Candidate: "b", Votes: 4}, {Candidate: "a", Votes: 3}, {Candidate: "c"}}},
+		{"Score", pb.VotingMethod_SCORE, []pb.Vote{{Scores: map[string]int32{"a": 3, "b": 5}}, {Scores: map[string]int32{"a": 4}}},
+			[]*pb.Tally{{Candidate: "a", Votes: 7}, {Candidate: "b", Votes: 5}, {Candidate: "c"}}},
+		{"Weighted score", pb.VotingMethod_SCORE, []pb.Vote{{Scores: map[string]int32{"a": 3, "b": 5}, Weight: 3}, {Scores: map[string]int32{"a": 4}}},
+			[]*pb.Tally{{Candidate: "b", Votes: 15}, {Candidate: "a", Votes: 13}, {Candidate: "c"}}},
+		{"Weighted Schulze", pb.VotingMethod_SCHULZE, []pb.Vote{{Rankings: []string{"a", "b", "c"}, Weight: 3}, {Rankings: []string{"b", "c", "a"}}, {Rankings: []string{"c", "b", "a"}}},
+			[]*pb.Tally{{Candidate: "a", Votes: 2}, {Candidate: "b", Votes: 1}, {Candidate: "c"}}},
+		{"Weighted STV", pb.VotingMethod_STV, []pb.Vote{{Rankings: []string{"a"}, Weight: 3}, {Rankings: []string{"b"}}, {Rankings: []string{"b", "a"}}},
+			[]*pb.Tally{{Candidate: "a", Votes: 3}, {Candidate: "b", Votes: 2}, {Candidate: "c"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := Count(&pb.Election{Candidates: candidates, VotingMethod: tt.method, MaxScore: 5}, tt.votes)
+			assert.Equal(t, int64(len(tt.votes)), results.GetBallots())
+			assert.Equal(t, tt.want, results.GetTallies())
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package tabulate
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// Order ranks candidates for breaking ties, the candidate with the lower rank
+// wins. Candidates without a rank come after ranked ones, by name. A nil
+// order breaks every tie by name.
+type Order map[string]int
+
+func (o Order) before(a, b string) bool {
+	ra, oka := o[a]
+	rb, okb := o[b]
+	switch {
+	case oka && okb && ra != rb:
+		return ra < rb
+	case oka != okb:
+		return oka
+	}
+	return a < b
+}
+
+// TieBreak returns the order the tie-break policy of the election puts the
+// candidates in, nil when ties are declared
+func TieBreak(election *pb.Election, votes []pb.Vote, candidates []string) Order {
+	switch election.GetTieBreak() {
+	case pb.TieBreak_RANDOM:
+		names := append([]string(nil), candidates...)
+		sort.Strings(names)
+		order := make(Order, len(names))
+		for i, p := range rand.New(rand.NewSource(election.GetSeed())).Perm(len(names)) {
+			order[names[p]] = i
+		}
+		return order
+	case pb.TieBreak_EARLIEST:
+		return earliest(votes)
+	case pb.TieBreak_LOT:
+		order := make(Order, len(election.GetLot()))
+		for i, c := range election.GetLot() {
+			order[c] = i
+		}
+		return order
+	}
+	return nil
+}
+
+// earliest ranks candidates by the earliest ballot choosing them, ranked
+// ballots choose their first preference and score ballots every candidate
+// they score above zero
+func earliest(votes []pb.Vote) Order {
+	first := make(map[string]int64)
+	for _, v := range votes {
+		t, err := ptypes.Timestamp(v.GetCast())
+		if err != nil {
+			continue
+		}
+		cast := t.UnixNano()
+		chosen := append([]string{v.GetCandidate()}, v.GetSelections()...)
+		if len(v.GetRankings()) > 0 {
+			chosen = append(chosen, v.GetRankings()[0])
+		}
+		for c, score := range v.GetScores() {
+			if score > 0 {
+				chosen = append(chosen, c)
+			}
+		}
+		for _, c := range chosen {
+			if at, ok := first[c]; c != "" && (!ok || cast < at) {
+				first[c] = cast
+			}
+		}
+	}
+
+	names := make([]string, 0, len(first))
+	for c := range first {
+		names = append(names, c)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if first[names[i]] != first[names[j]] {
+			return first[names[i]] < first[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	order := make(Order, len(names))
+	for i, c := range names {
+		order[c] = i
+	}
+	return order
+}
+
+// Tie records the tie-break policy of the election, the order it put the
+// candidates in and the candidates tied for the lead of the results, or for
+// last place when a count stopped there. It is nil when ties are declared and
+// there is none.
+func Tie(election *pb.Election, order Order, results *pb.Results) *pb.Tie {
+	tie := &pb.Tie{Policy: election.GetTieBreak()}
+	if tie.Policy == pb.TieBreak_RANDOM {
+		tie.Seed = election.GetSeed()
+	}
+	for c := range order {
+		tie.Order = append(tie.Order, c)
+	}
+	sort.Slice(tie.Order, func(i, j int) bool {
+		return order.before(tie.Order[i], tie.Order[j])
+	})
+
+	if runoff := results.GetRunoff(); runoff != nil {
+		if runoff.GetWinner() == "" {
+			for _, f := range runoff.GetFinalists() {
+				tie.Tied = append(tie.Tied, f.GetCandidate())
+			}
+		}
+	} else if tallies := results.GetTallies(); len(tallies) > 1 && tallies[0].GetVotes() == tallies[1].GetVotes() && len(results.GetStvRounds()) == 0 {
+		for _, t := range tallies {
+			if t.GetVotes() == tallies[0].GetVotes() {
+				tie.Tied = append(tie.Tied, t.GetCandidate())
+			}
+		}
+	} else {
+		tie.Tied = lastPlace(results)
+	}
+
+	if tie.Policy == pb.TieBreak_DECLARE && len(tie.Tied) == 0 {
+		return nil
+	}
+	return tie
+}
+
+// lastPlace returns the candidates tied for last place in the final round of
+// a count that stopped without a winner for want of a tie-break order
+func lastPlace(results *pb.Results) []string {
+	var tied []string
+	if rounds := results.GetRounds(); len(rounds) > 0 {
+		counts := rounds[len(rounds)-1].GetCounts()
+		var continuing int64
+		for _, c := range counts {
+			continuing += c.GetVotes()
+		}
+		if len(counts) < 2 || counts[0].GetVotes()*2 > continuing {
+			return nil
+		}
+		for _, c := range counts {
+			if c.GetVotes() == counts[len(counts)-1].GetVotes() {
+				tied = append(tied, c.GetCandidate())
+			}
+		}
+	}
+	if rounds := results.GetStvRounds(); len(rounds) > 0 {
+		final := rounds[len(rounds)-1]
+		counts := final.GetCounts()
+		if len(counts) < 2 || len(final.GetElected()) > 0 || final.GetEliminated() != "" {
+			return nil
+		}
+		for _, c := range counts {
+			if c.GetVotes() == counts[len(counts)-1].GetVotes() {
+				tied = append(tied, c.GetCandidate())
+			}
+		}
+	}
+	return tied
+}
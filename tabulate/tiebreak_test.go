@@ -0,0 +1,108 @@
+package tabulate
+
+import (
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TieBreak(t *testing.T) {
+	candidates := []string{"a", "b", "c"}
+	votes := []pb.Vote{
+		{Candidate: "b", Cast: &timestamp.Timestamp{Seconds: 20}},
+		{Rankings: []string{"c", "a"}, Cast: &timestamp.Timestamp{Seconds: 10}},
+		{Scores: map[string]int32{"a": 0, "b": 2}, Cast: &timestamp.Timestamp{Seconds: 5}},
+		{Candidate: "a"},
+	}
+
+	tests := []struct {
+		name     string
+		election *pb.Election
+		want     Order
+	}{
+		{"Declare", &pb.Election{}, nil},
+		{"Lot", &pb.Election{TieBreak: pb.TieBreak_LOT, Lot: []string{"c", "a", "b"}}, Order{"c": 0, "a": 1, "b": 2}},
+		{"Earliest", &pb.Election{TieBreak: pb.TieBreak_EARLIEST}, Order{"b": 0, "c": 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TieBreak(tt.election, votes, candidates))
+		})
+	}
+
+	t.Run("Random", func(t *testing.T) {
+		election := &pb.Election{TieBreak: pb.TieBreak_RANDOM, Seed: 42}
+		order := TieBreak(election, nil, candidates)
+		assert.Len(t, order, len(candidates))
+		assert.Equal(t, order, TieBreak(election, nil, []string{"c", "b", "a"}))
+	})
+}
+
+func Test_Order(t *testing.T) {
+	order := Order{"c": 0, "b": 1}
+	assert.True(t, order.before("c", "b"))
+	assert.True(t, order.before("b", "a"))
+	assert.True(t, order.before("a", "d"))
+	assert.True(t, Order(nil).before("a", "b"))
+}
+
+func Test_Count_tie(t *testing.T) {
+	votes := []pb.Vote{{Candidate: "a"}, {Candidate: "b"}}
+
+	tests := []struct {
+		name     string
+		election *pb.Election
+		tallies  []*pb.Tally
+		tie      *pb.Tie
+		passed   bool
+	}{
+		{"Declared tie", &pb.Election{Candidates: []string{"a", "b"}, Rules: &pb.Rules{Majority: 0.5}},
+			[]*pb.Tally{{Candidate: "a", Votes: 1}, {Candidate: "b", Votes: 1}},
+			&pb.Tie{Tied: []string{"a", "b"}}, false},
+		{"Lot", &pb.Election{Candidates: []string{"a", "b"}, TieBreak: pb.TieBreak_LOT, Lot: []string{"b", "a"}, Rules: &pb.Rules{Majority: 0.5}},
+			[]*pb.Tally{{Candidate: "b", Votes: 1}, {Candidate: "a", Votes: 1}},
+			&pb.Tie{Policy: pb.TieBreak_LOT, Order: []string{"b", "a"}, Tied: []string{"b", "a"}}, true},
+		{"Random seed is recorded", &pb.Election{Candidates: []string{"a", "b"}, TieBreak: pb.TieBreak_RANDOM, Seed: 7, Rules: &pb.Rules{Majority: 0.5}},
+			nil, &pb.Tie{Policy: pb.TieBreak_RANDOM, Seed: 7}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := Count(tt.election, votes)
+			if tt.tallies != nil {
+				assert.Equal(t, tt.tallies, results.GetTallies())
+				assert.Equal(t, tt.tie, results.GetTie())
+			} else {
+				assert.Equal(t, tt.tie.GetSeed(), results.GetTie().GetSeed())
+				assert.Equal(t, results.GetTallies()[0].GetCandidate(), results.GetTie().GetOrder()[0])
+			}
+			assert.Equal(t, tt.passed, results.GetVerdict().GetPassed())
+		})
+	}
+}
+
+func Test_Count_tie_last(t *testing.T) {
+	var votes []pb.Vote
+	votes = append(votes, ranked(3, "a")...)
+	votes = append(votes, ranked(2, "b")...)
+	votes = append(votes, ranked(2, "c")...)
+
+	tests := []struct {
+		name     string
+		election *pb.Election
+		tie      *pb.Tie
+	}{
+		{"Declared instant-runoff", &pb.Election{Candidates: []string{"a", "b", "c"}, VotingMethod: pb.VotingMethod_RANKED},
+			&pb.Tie{Tied: []string{"b", "c"}}},
+		{"Declared STV", &pb.Election{Candidates: []string{"a", "b", "c"}, VotingMethod: pb.VotingMethod_STV, Seats: 1},
+			&pb.Tie{Tied: []string{"b", "c"}}},
+		{"Lot breaks instant-runoff", &pb.Election{Candidates: []string{"a", "b", "c"}, VotingMethod: pb.VotingMethod_RANKED, TieBreak: pb.TieBreak_LOT, Lot: []string{"a", "b", "c"}},
+			&pb.Tie{Policy: pb.TieBreak_LOT, Order: []string{"a", "b", "c"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.tie, Count(tt.election, votes).GetTie())
+		})
+	}
+}
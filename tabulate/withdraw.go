@@ -0,0 +1,68 @@
+package tabulate
+
+import (
+	"github.com/ednesic/vote-test/pb"
+)
+
+// withdraw applies the withdrawal policy of the election to the counted
+// ballots. Kept ballots count as cast. Otherwise the withdrawn candidates
+// leave the count and ballots skip them for their next preference, unless
+// voided ballots chose them first or only. It returns the ballots and
+// candidates left with the weight of the void ballots.
+func withdraw(election *pb.Election, votes []pb.Vote, candidates []string) ([]pb.Vote, []string, int64) {
+	if len(election.GetWithdrawn()) == 0 || election.GetWithdrawal() == pb.Withdrawal_KEEP {
+		return votes, candidates, 0
+	}
+
+	out := make(map[string]bool, len(election.GetWithdrawn()))
+	for _, c := range election.GetWithdrawn() {
+		out[c] = true
+	}
+	running := without(candidates, out)
+
+	var voided int64
+	left := make([]pb.Vote, 0, len(votes))
+	for _, v := range votes {
+		if election.GetWithdrawal() == pb.Withdrawal_VOID && void(&v, out) {
+			voided += Weight(&v)
+			continue
+		}
+		v.Rankings = without(v.GetRankings(), out)
+		v.Selections = without(v.GetSelections(), out)
+		left = append(left, v)
+	}
+	return left, running, voided
+}
+
+// void tells whether the first or only choice of the ballot withdrew, the
+// first of its rankings or every candidate it selects or scores
+func void(v *pb.Vote, out map[string]bool) bool {
+	if out[v.GetCandidate()] {
+		return true
+	}
+	if rankings := v.GetRankings(); len(rankings) > 0 {
+		return out[rankings[0]]
+	}
+	if selections := v.GetSelections(); len(selections) > 0 {
+		return len(without(selections, out)) == 0
+	}
+	scored := false
+	for c, score := range v.GetScores() {
+		if score > 0 && !out[c] {
+			return false
+		}
+		scored = scored || score > 0
+	}
+	return scored
+}
+
+// without returns the candidates that are not out
+func without(candidates []string, out map[string]bool) []string {
+	var left []string
+	for _, c := range candidates {
+		if !out[c] {
+			left = append(left, c)
+		}
+	}
+	return left
+}
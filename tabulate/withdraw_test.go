@@ -0,0 +1,72 @@
+package tabulate
+
+import (
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Count_withdrawn(t *testing.T) {
+	var votes []pb.Vote
+	votes = append(votes, ranked(3, "a", "b")...)
+	votes = append(votes, ranked(2, "c", "b")...)
+	votes = append(votes, ranked(2, "b")...)
+	votes = append(votes, pb.Vote{Rankings: []string{"c", "a"}, Weight: 2})
+	candidates := []string{"a", "b", "c"}
+
+	tests := []struct {
+		name       string
+		withdrawal pb.Withdrawal
+		tallies    []*pb.Tally
+		voided     int64
+	}{
+		{"Keep", pb.Withdrawal_KEEP, []*pb.Tally{{Candidate: "c", Votes: 4}, {Candidate: "a", Votes: 3}, {Candidate: "b", Votes: 2}}, 0},
+		{"Void", pb.Withdrawal_VOID, []*pb.Tally{{Candidate: "c", Votes: 4}, {Candidate: "a", Votes: 3}}, 2},
+		{"Redistribute", pb.Withdrawal_REDISTRIBUTE, []*pb.Tally{{Candidate: "c", Votes: 4}, {Candidate: "a", Votes: 3}}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			election := &pb.Election{Candidates: candidates, VotingMethod: pb.VotingMethod_RANKED, Withdrawn: []string{"b"}, Withdrawal: tt.withdrawal}
+			results := Count(election, votes)
+			assert.Equal(t, tt.tallies, results.GetRounds()[0].GetCounts())
+			assert.Equal(t, tt.voided, results.GetVoided())
+			assert.Equal(t, []string{"b"}, results.GetWithdrawn())
+			assert.Equal(t, int64(len(votes)), results.GetBallots())
+		})
+	}
+}
+
+func Test_void(t *testing.T) {
+	out := map[string]bool{"b": true}
+	assert.True(t, void(&pb.Vote{Candidate: "b"}, out))
+	assert.True(t, void(&pb.Vote{Rankings: []string{"b", "a"}}, out))
+	assert.False(t, void(&pb.Vote{Rankings: []string{"a", "b"}}, out))
+	assert.True(t, void(&pb.Vote{Selections: []string{"b"}}, out))
+	assert.False(t, void(&pb.Vote{Selections: []string{"a", "b"}}, out))
+	assert.True(t, void(&pb.Vote{Scores: map[string]int32{"a": 0, "b": 1}}, out))
+	assert.False(t, void(&pb.Vote{Scores: map[string]int32{"a": 3, "b": 1}}, out))
+	assert.False(t, void(&pb.Vote{Scores: map[string]int32{"a": 0, "b": 0}}, out))
+}
+
+func Test_Count_withdrawn_approval(t *testing.T) {
+	votes := []pb.Vote{{Selections: []string{"a", "b"}}, {Selections: []string{"b"}}, {Selections: []string{"c"}}}
+	election := &pb.Election{Candidates: []string{"a", "b", "c"}, VotingMethod: pb.VotingMethod_APPROVAL, Withdrawn: []string{"b"}, Withdrawal: pb.Withdrawal_VOID}
+
+	results := Count(election, votes)
+	assert.Equal(t, []*pb.Tally{{Candidate: "a", Votes: 1}, {Candidate: "c", Votes: 1}}, results.GetTallies())
+	assert.Equal(t, int64(1), results.GetVoided())
+}
+
+func Test_Count_withdrawn_contest(t *testing.T) {
+	election := &pb.Election{Withdrawal: pb.Withdrawal_VOID, Contests: []*pb.Contest{{Id: "q1", Candidates: []string{"yes", "no", "maybe"}, Withdrawn: []string{"maybe"}}}}
+	votes := []pb.Vote{
+		{Answers: []*pb.Answer{{Contest: "q1", Candidate: "maybe"}}},
+		{Answers: []*pb.Answer{{Contest: "q1", Candidate: "yes"}}},
+	}
+
+	results := Count(election, votes).GetContests()[0].GetResults()
+	assert.Equal(t, []*pb.Tally{{Candidate: "yes", Votes: 1}, {Candidate: "no"}}, results.GetTallies())
+	assert.Equal(t, []string{"maybe"}, results.GetWithdrawn())
+	assert.Equal(t, int64(1), results.GetVoided())
+}
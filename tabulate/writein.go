@@ -0,0 +1,98 @@
+package tabulate
+
+import (
+	"sort"
+
+	"github.com/ednesic/vote-test/ballot"
+	"github.com/ednesic/vote-test/pb"
+)
+
+// resolve sets blank ballots apart and, on elections allowing write-ins,
+// rewrites every choice to the candidate or normalized write-in it counts
+// for. The write-in names follow the candidates and are reported with the
+// spellings grouped under them.
+func resolve(election *pb.Election, votes []pb.Vote) ([]pb.Vote, []string, []*pb.WriteIn, int64) {
+	var (
+		counted     = make([]pb.Vote, 0, len(votes))
+		abstentions int64
+		groups      = make(map[string]*pb.WriteIn)
+		spellings   = make(map[string]map[string]bool)
+		candidate   = make(map[string]bool, len(election.GetCandidates()))
+	)
+	for _, c := range election.GetCandidates() {
+		candidate[c] = true
+	}
+
+	for _, v := range votes {
+		if v.GetAbstain() {
+			abstentions++
+			continue
+		}
+		if !election.GetWriteIns() {
+			counted = append(counted, v)
+			continue
+		}
+
+		named := make(map[string]bool)
+		name := func(choice string) string {
+			r := ballot.Resolve(election, choice)
+			if candidate[r] {
+				return r
+			}
+			if groups[r] == nil {
+				groups[r] = &pb.WriteIn{Name: r}
+				spellings[r] = make(map[string]bool)
+			}
+			if !named[r] {
+				groups[r].Ballots += Weight(&v)
+				named[r] = true
+			}
+			if !spellings[r][choice] {
+				spellings[r][choice] = true
+				groups[r].Spellings = append(groups[r].Spellings, choice)
+			}
+			return r
+		}
+
+		r := pb.Vote{Weight: v.GetWeight(), Cast: v.GetCast()}
+		if v.GetCandidate() != "" {
+			r.Candidate = name(v.GetCandidate())
+		}
+		for _, c := range v.GetSelections() {
+			r.Selections = append(r.Selections, name(c))
+		}
+		for _, c := range v.GetRankings() {
+			r.Rankings = append(r.Rankings, name(c))
+		}
+		if len(v.GetScores()) > 0 {
+			r.Scores = make(map[string]int32, len(v.GetScores()))
+			for c, score := range v.GetScores() {
+				r.Scores[name(c)] = score
+			}
+		}
+		counted = append(counted, r)
+	}
+
+	candidates := election.GetCandidates()
+	writeIns := make([]*pb.WriteIn, 0, len(groups))
+	for _, g := range groups {
+		sort.Strings(g.Spellings)
+		writeIns = append(writeIns, g)
+	}
+	sort.Slice(writeIns, func(i, j int) bool {
+		if writeIns[i].GetBallots() != writeIns[j].GetBallots() {
+			return writeIns[i].GetBallots() > writeIns[j].GetBallots()
+		}
+		return writeIns[i].GetName() < writeIns[j].GetName()
+	})
+	if len(writeIns) == 0 {
+		return counted, candidates, nil, abstentions
+	}
+
+	names := make([]string, 0, len(writeIns))
+	for _, g := range writeIns {
+		names = append(names, g.GetName())
+	}
+	sort.Strings(names)
+	return counted, append(append([]string{}, candidates...), names...), writeIns, abstentions
+}
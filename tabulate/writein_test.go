@@ -0,0 +1,57 @@
+package tabulate
+
+import (
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Count_writeIns(t *testing.T) {
+	tests := []struct {
+		name     string
+		election *pb.Election
+		votes    []pb.Vote
+		want     *pb.Results
+	}{
+		{"Write-ins grouped by normalized name", &pb.Election{Candidates: []string{"a", "b"}, WriteIns: true}, []pb.Vote{
+			{Candidate: "Jane Doe"},
+			{Candidate: " jane  DOE", Weight: 2},
+			{Candidate: "A"},
+			{Candidate: "zed"},
+		}, &pb.Results{
+			Ballots: 4,
+			Tallies: []*pb.Tally{{Candidate: "jane doe", Votes: 3}, {Candidate: "a", Votes: 1}, {Candidate: "zed", Votes: 1}, {Candidate: "b"}},
+			WriteIns: []*pb.WriteIn{
+				{Name: "jane doe", Ballots: 3, Spellings: []string{" jane  DOE", "Jane Doe"}},
+				{Name: "zed", Ballots: 1, Spellings: []string{"zed"}},
+			},
+		}},
+		{"Ranked write-ins run with the candidates", &pb.Election{Candidates: []string{"a"}, WriteIns: true, VotingMethod: pb.VotingMethod_RANKED}, []pb.Vote{
+			{Rankings: []string{"Bob", "a"}},
+			{Rankings: []string{"bob"}},
+			{Rankings: []string{"a"}},
+		}, &pb.Results{
+			Ballots: 3,
+			Tallies: []*pb.Tally{{Candidate: "bob", Votes: 2}, {Candidate: "a", Votes: 1}},
+			Rounds:  []*pb.Round{{Number: 1, Counts: []*pb.Tally{{Candidate: "bob", Votes: 2}, {Candidate: "a", Votes: 1}}}},
+			WriteIns: []*pb.WriteIn{
+				{Name: "bob", Ballots: 2, Spellings: []string{"Bob", "bob"}},
+			},
+		}},
+		{"Abstentions count in ballots only", &pb.Election{Candidates: []string{"a", "b"}, Abstain: true}, []pb.Vote{
+			{Candidate: "a"},
+			{Abstain: true},
+			{Abstain: true, Weight: 4},
+		}, &pb.Results{
+			Ballots:     3,
+			Tallies:     []*pb.Tally{{Candidate: "a", Votes: 1}, {Candidate: "b"}},
+			Abstentions: 2,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Count(tt.election, tt.votes))
+		})
+	}
+}
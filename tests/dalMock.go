@@ -10,11 +10,27 @@ func (m *DataAccessLayerMock) Insert(collName string, doc interface{}) error {
 	args := m.Called(collName, doc)
 	return args.Error(0)
 }
+
+func (m *DataAccessLayerMock) InsertAll(collName string, docs []interface{}) error {
+	args := m.Called(collName, docs)
+	return args.Error(0)
+}
+
 func (m *DataAccessLayerMock) FindOne(collName string, query interface{}, doc interface{}) error {
 	args := m.Called(collName, query, doc)
 	return args.Error(0)
 }
 
+func (m *DataAccessLayerMock) FindAll(collName string, query interface{}, docs interface{}) error {
+	args := m.Called(collName, query, docs)
+	return args.Error(0)
+}
+
+func (m *DataAccessLayerMock) FindFirst(collName string, query interface{}, sort string, doc interface{}) error {
+	args := m.Called(collName, query, sort, doc)
+	return args.Error(0)
+}
+
 func (m *DataAccessLayerMock) Update(collName string, selector interface{}, update interface{}) error {
 	args := m.Called(collName, selector, update)
 	return args.Error(0)
@@ -30,7 +46,16 @@ func (m *DataAccessLayerMock) Remove(collName string, selector interface{}) erro
 	return args.Error(0)
 }
 
-func (m *DataAccessLayerMock) EnsureIndex(collName string, field string) error {
-	args := m.Called(collName, field)
+func (m *DataAccessLayerMock) EnsureIndex(collName string, unique bool, fields ...string) error {
+	args := m.Called(collName, unique, fields)
 	return args.Error(0)
 }
+
+func (m *DataAccessLayerMock) Ping() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *DataAccessLayerMock) Close() {
+	m.Called()
+}
@@ -32,3 +32,41 @@ func (s StanConnMock) Close() error {
 func (s StanConnMock) NatsConn() *nats.Conn {
 	return nil
 }
+
+type SubscriptionMock struct {
+	mock.Mock
+}
+
+func (s *SubscriptionMock) ClearMaxPending() error {
+	return nil
+}
+
+func (s *SubscriptionMock) Pending() (int, int, error) {
+	return 0, 0, nil
+}
+
+func (s *SubscriptionMock) MaxPending() (int, int, error) {
+	return 0, 0, nil
+}
+
+func (s *SubscriptionMock) Delivered() (int64, error) {
+	return 0, nil
+}
+
+func (s *SubscriptionMock) Dropped() (int, error) {
+	return 0, nil
+}
+
+func (s *SubscriptionMock) IsValid() bool {
+	return true
+}
+
+func (s *SubscriptionMock) Unsubscribe() error {
+	args := s.Called()
+	return args.Error(0)
+}
+
+func (s *SubscriptionMock) Close() error {
+	args := s.Called()
+	return args.Error(0)
+}
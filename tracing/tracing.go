@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName  = "github.com/ednesic/vote-test/tracing"
+	endpointEnv = "OTEL_EXPORTER_OTLP_ENDPOINT"
+)
+
+// Client is the HTTP client services call each other with, it sends the
+// trace context of the request context along
+var Client = &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+
+// Init propagates W3C trace context and, when OTEL_EXPORTER_OTLP_ENDPOINT is
+// set, exports the spans of the service to that OTLP collector, the other
+// standard OTEL_EXPORTER_OTLP_* variables apply too. The returned func flushes
+// the spans left and stops exporting.
+func Init(ctx context.Context, service string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if os.Getenv(endpointEnv) == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(service))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Handler wraps the router to start a server span for every request, named
+// after the method and route template. The span starts before the router
+// serves the request, so the route is matched for the name here.
+func Handler(router *mux.Router) http.Handler {
+	return otelhttp.NewHandler(router, "", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		var match mux.RouteMatch
+		if router.Match(r, &match) && match.Route != nil {
+			if tpl, err := match.Route.GetPathTemplate(); err == nil {
+				return r.Method + " " + tpl
+			}
+		}
+		return r.Method
+	}))
+}
+
+// Inject returns the trace context of ctx to carry in a message
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return carrier
+}
+
+// Extract returns a context continuing the trace carried in a message
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// Start starts a span of the given kind
+func Start(ctx context.Context, name string, kind trace.SpanKind) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithSpanKind(kind))
+}
+
+// End records the error of the work of the span, if any, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Step runs a step of the work of ctx in a span of its own
+func Step(ctx context.Context, name string, step func() error) error {
+	_, span := Start(ctx, name, trace.SpanKindInternal)
+	err := step()
+	End(span, err)
+	return err
+}
@@ -0,0 +1,100 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setup() *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return exporter
+}
+
+func Test_injectExtract(t *testing.T) {
+	exporter := setup()
+
+	ctx, producer := Start(context.Background(), "publish", trace.SpanKindProducer)
+	carrier := Inject(ctx)
+	producer.End()
+	assert.Contains(t, carrier, "traceparent")
+
+	_, consumer := Start(Extract(context.Background(), carrier), "process", trace.SpanKindConsumer)
+	consumer.End()
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, spans[0].SpanContext.TraceID(), spans[1].SpanContext.TraceID())
+	assert.Equal(t, spans[0].SpanContext.SpanID(), spans[1].Parent.SpanID())
+	assert.Empty(t, Inject(context.Background()))
+}
+
+func Test_step(t *testing.T) {
+	exporter := setup()
+
+	ctx, parent := Start(context.Background(), "process", trace.SpanKindConsumer)
+	assert.Nil(t, Step(ctx, "store", func() error { return nil }))
+	err := errors.New("test error")
+	assert.Equal(t, err, Step(ctx, "tally", func() error { return err }))
+	End(parent, nil)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 3)
+	assert.Equal(t, "store", spans[0].Name)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Equal(t, "tally", spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Equal(t, spans[2].SpanContext.SpanID(), spans[1].Parent.SpanID())
+}
+
+func Test_Handler(t *testing.T) {
+	exporter := setup()
+
+	router := mux.NewRouter()
+	router.HandleFunc("/election/{id}", func(w http.ResponseWriter, r *http.Request) {}).Methods(http.MethodGet)
+	handler := Handler(router)
+
+	req, err := http.NewRequest("GET", "http://localhost:9223/election/1", nil)
+	assert.Nil(t, err, "could not create request")
+	req.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	req, err = http.NewRequest("GET", "http://localhost:9223/unknown", nil)
+	assert.Nil(t, err, "could not create request")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "GET /election/{id}", spans[0].Name)
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", spans[0].SpanContext.TraceID().String())
+	assert.Equal(t, "GET", spans[1].Name)
+}
+
+func Test_Init(t *testing.T) {
+	t.Setenv(endpointEnv, "")
+	provider := otel.GetTracerProvider()
+
+	stop, err := Init(context.Background(), "test")
+	assert.Nil(t, err)
+	assert.Equal(t, provider, otel.GetTracerProvider())
+	assert.Nil(t, stop(context.Background()))
+
+	t.Setenv(endpointEnv, "http://localhost:4318")
+	stop, err = Init(context.Background(), "test")
+	assert.Nil(t, err)
+	assert.IsType(t, &sdktrace.TracerProvider{}, otel.GetTracerProvider())
+	assert.Nil(t, stop(context.Background()))
+}
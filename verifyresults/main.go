@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/ednesic/vote-test/sign"
+)
+
+const usage = `usage: verifyresults -key <public key file> <results file>
+
+Checks a results file downloaded from GET /election/{id}/results against the
+public key served by the election service at /.well-known/election-results-key.
+`
+
+func main() {
+	keyPath := flag.String("key", "", "PEM encoded public key of the election service")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *keyPath == "" || flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	key, err := ioutil.ReadFile(*keyPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	results, err := verify(key, data)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("results of election %d are signed by the election service\n", results.GetElectionId())
+}
+
+func verify(key []byte, data []byte) (*pb.Results, error) {
+	var signed pb.SignedResults
+
+	pub, err := sign.ParsePublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &signed)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sign.Verify(pub, &signed)
+	if err != nil {
+		return nil, err
+	}
+	return signed.GetResults(), nil
+}
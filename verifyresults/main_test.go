@@ -0,0 +1,49 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/ednesic/vote-test/pb"
+	"github.com/ednesic/vote-test/sign"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_verify(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	key, _ := sign.MarshalPublicKey(pub)
+	otherKey, _ := sign.MarshalPublicKey(otherPub)
+
+	signed, _ := sign.Results(priv, &pb.Results{ElectionId: 1, Ballots: 1, Tallies: []*pb.Tally{{Candidate: "a", Votes: 1}}})
+	data, _ := json.Marshal(signed)
+
+	signed.Results.Tallies[0].Votes = 2
+	tampered, _ := json.Marshal(signed)
+
+	tests := []struct {
+		name    string
+		key     []byte
+		data    []byte
+		wantErr bool
+	}{
+		{"Valid results", key, data, false},
+		{"Other key", otherKey, data, true},
+		{"Tampered results", key, tampered, true},
+		{"Invalid key", []byte("key"), data, true},
+		{"Invalid results file", key, []byte("{"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := verify(tt.key, tt.data)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, int32(1), results.GetElectionId())
+		})
+	}
+}
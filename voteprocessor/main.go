@@ -1,20 +1,36 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
-	"runtime"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/ednesic/vote-test/db"
+	"github.com/ednesic/vote-test/health"
+	"github.com/ednesic/vote-test/invite"
+	"github.com/ednesic/vote-test/metrics"
 	"github.com/ednesic/vote-test/pb"
+	"github.com/ednesic/vote-test/tracing"
 	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/nats-io/go-nats-streaming"
 	"github.com/nats-io/nuid"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
 )
 
 const (
@@ -25,25 +41,60 @@ const (
 	errParseTimestamp   = "Failed to parse timestamp"
 	errElectionNotFound = "Could not get election:"
 	errElectionEnded    = "Election has ended"
+	errEnsureIndex      = "Error in err Ensurance"
+	errAlreadyVoted     = "Voter already took part in the election"
+	errNoVoter          = "Vote has no voter"
+	errNoReceipt        = "Vote has no receipt"
+	errRollback         = "Failed to revoke admission of unstored ballot"
+	errNoCode           = "Invite only election requires a code"
+	errInvalidCode      = "Invalid or already used code"
+	errNoWeight         = "Voter has no weight in the election"
+	errInterrupt        = "Shutting down"
+	errShutdown         = "Failed to shut down cleanly"
+	errListen           = "HTTP server failed"
+	errTracing          = "Failed to set up tracing"
 
 	voteProcessed   = "Vote processed"
 	initVoteProcMsg = "Processor running"
+	listenMsg       = "HTTP Sever listening"
+	mongoName       = "mongo"
+	natsName        = "nats"
+	traceName       = "vote-processor"
+
+	voteElecIDKey = "electionid"
+	voterKey      = "voter"
+	codeHashKey   = "hash"
+	codeUsedKey   = "used"
+
+	reasonDecode   = "decode"
+	reasonValidate = "validation"
+	reasonWeight   = "weight"
+	reasonAdmit    = "admission"
+	reasonStore    = "store"
+
+	pingTimeout = 2 * time.Second
 )
 
 type spec struct {
-	VoteChannel     string `envconfig:"VOTE_CHANNEL" default:"create-vote"`
-	NatsClusterID   string `envconfig:"NATS_CLUSTER_ID" default:"test-cluster"`
-	NatsServer      string `envconfig:"NATS_SERVER" default:"localhost:4222"`
-	ClientID        string `envconfig:"CLIENT_ID" default:"vote-processor"`
-	DurableID       string `envconfig:"DURABLE_ID" default:"vote-processor"`
-	QueueGroup      string `envconfig:"QUEUE_GROUP" default:"vote-processor"`
-	MgoURL          string `envconfig:"MONGO_URL" default:"localhost:27017"`
-	Coll            string `envconfig:"COLLECTION" default:"vote"`
-	Database        string `envconfig:"DATABASE" default:"elections"`
-	ElectionService string `envconfig:"ELECTION_SERVICE" default:"http://localhost:9223"`
+	Port            string        `envconfig:"PORT" default:"9224"`
+	VoteChannel     string        `envconfig:"VOTE_CHANNEL" default:"create-vote"`
+	NatsClusterID   string        `envconfig:"NATS_CLUSTER_ID" default:"test-cluster"`
+	NatsServer      string        `envconfig:"NATS_SERVER" default:"localhost:4222"`
+	ClientID        string        `envconfig:"CLIENT_ID" default:"vote-processor"`
+	DurableID       string        `envconfig:"DURABLE_ID" default:"vote-processor"`
+	QueueGroup      string        `envconfig:"QUEUE_GROUP" default:"vote-processor"`
+	MgoURL          string        `envconfig:"MONGO_URL" default:"localhost:27017"`
+	Coll            string        `envconfig:"COLLECTION" default:"vote"`
+	PartColl        string        `envconfig:"PARTICIPATION_COLLECTION" default:"participation"`
+	CodeColl        string        `envconfig:"CODE_COLLECTION" default:"code"`
+	WeightColl      string        `envconfig:"WEIGHT_COLLECTION" default:"weight"`
+	Database        string        `envconfig:"DATABASE" default:"elections"`
+	ElectionService string        `envconfig:"ELECTION_SERVICE" default:"http://localhost:9223"`
+	ShutdownTimeout time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"30s"`
 
-	mgoDal db.DataAccessLayer
-	logger *zap.Logger
+	mgoDal   db.DataAccessLayer
+	logger   *zap.Logger
+	inFlight sync.WaitGroup
 }
 
 func main() {
@@ -61,6 +112,11 @@ func main() {
 		s.logger.Fatal(errEnvVarFail, zap.Error(err))
 	}
 
+	stopTracing, err := tracing.Init(context.Background(), traceName)
+	if err != nil {
+		s.logger.Fatal(errTracing, zap.Error(err))
+	}
+
 	stanConn, err := stan.Connect(
 		s.NatsClusterID,
 		nuid.Next(),
@@ -74,7 +130,11 @@ func main() {
 		s.logger.Fatal(errConnFail, zap.Error(err))
 	}
 
-	sub, err := stanConn.QueueSubscribe(s.VoteChannel, s.QueueGroup, s.procVote)
+	sub, err := stanConn.QueueSubscribe(s.VoteChannel, s.QueueGroup, func(msg *stan.Msg) {
+		s.inFlight.Add(1)
+		defer s.inFlight.Done()
+		s.procVote(msg)
+	})
 	if err != nil {
 		s.logger.Fatal(errConnFail, zap.Error(err))
 	}
@@ -83,55 +143,320 @@ func main() {
 	if err != nil {
 		s.logger.Fatal(errConnFail, zap.Error(err))
 	}
+	s.mgoDal = metrics.DAL(s.mgoDal)
+	metrics.Pending(sub)
+
+	err = s.mgoDal.EnsureIndex(s.PartColl, true, voteElecIDKey, voterKey)
+	if err != nil {
+		s.logger.Fatal(errEnsureIndex, zap.Error(err))
+	}
+
+	srv := &http.Server{
+		Addr:    ":" + s.Port,
+		Handler: s.healthRoutes(stanConn),
+	}
+	go func() {
+		s.logger.Info(listenMsg, zap.String("Port", s.Port))
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			s.logger.Fatal(errListen, zap.Error(err))
+		}
+	}()
 
 	s.logger.Info(initVoteProcMsg)
 	defer s.logger.Sync()
-	runtime.Goexit()
-	defer sub.Unsubscribe()
-	defer stanConn.Close()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	s.logger.Info(errInterrupt, zap.Stringer("Signal", <-signals))
+
+	err = s.shutdown(srv, sub, stanConn)
+	if err != nil {
+		s.logger.Error(errShutdown, zap.Error(err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+	defer cancel()
+	err = stopTracing(ctx)
+	if err != nil {
+		s.logger.Error(errShutdown, zap.Error(err))
+	}
+}
+
+// healthRoutes serves the liveness, readiness and metrics of the processor
+func (s *spec) healthRoutes(conn stan.Conn) http.Handler {
+	router := http.NewServeMux()
+	router.HandleFunc(health.LivePath, health.Live)
+	router.Handle(metrics.Path, metrics.Handler())
+	router.HandleFunc(health.ReadyPath, health.Ready(map[string]health.Check{
+		mongoName: func() error { return s.mgoDal.Ping() },
+		natsName:  func() error { return health.Nats(conn) },
+	}, pingTimeout))
+	return router
+}
+
+// shutdown stops serving health checks and receiving votes and waits up to
+// the shutdown timeout for the votes in flight before closing the nats
+// connection and the mongo session
+func (s *spec) shutdown(srv *http.Server, sub stan.Subscription, conn stan.Conn) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+	defer cancel()
+	defer s.mgoDal.Close()
+	defer conn.Close()
+
+	err := srv.Shutdown(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = sub.Unsubscribe()
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.inFlight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func (s *spec) procVote(msg *stan.Msg) {
 	var (
-		err error
-		v   pb.Vote
+		err      error
+		reason   string
+		v        pb.Vote
+		election *pb.Election
 	)
+	carrier, err := decode(msg.Data, &v)
+	ctx, span := tracing.Start(tracing.Extract(context.Background(), carrier), "process "+s.VoteChannel, trace.SpanKindConsumer)
 	defer func() {
-		s.logger.Info(voteProcessed, zap.Error(err), zap.Int32("electionId", v.ElectionId), zap.String("User", v.GetCandidate()))
+		tracing.End(span, err)
+		if err != nil {
+			metrics.VotesRejected.WithLabelValues(reason).Inc()
+		} else {
+			metrics.VotesProcessed.Inc()
+		}
+		s.logger.Info(voteProcessed, zap.Error(err), zap.Int32("electionId", v.ElectionId))
 	}()
 
-	err = proto.Unmarshal(msg.Data, &v)
 	if err != nil {
+		reason = reasonDecode
+		return
+	}
+
+	start := time.Now()
+	election, err = validateVote(ctx, s.ElectionService, &v)
+	metrics.ValidateLatency.Observe(time.Since(start).Seconds())
+	if err != nil {
+		reason = reasonValidate
+		return
+	}
+
+	err = tracing.Step(ctx, reasonWeight, func() error {
+		return weigh(s.mgoDal, s.WeightColl, election, &v)
+	})
+	if err != nil {
+		reason = reasonWeight
 		return
 	}
 
-	err = validateVote(s.ElectionService, &v)
+	err = tracing.Step(ctx, reasonAdmit, func() error {
+		return s.admit(election, &v)
+	})
 	if err != nil {
+		reason = reasonAdmit
 		return
 	}
 
-	err = vote(s.mgoDal, s.Coll, &v)
+	stamp(election, &v)
+	err = tracing.Step(ctx, reasonStore, func() error {
+		return vote(s.mgoDal, s.Coll, &v)
+	})
+	if err != nil {
+		reason = reasonStore
+		s.revoke(election, &v)
+	}
+}
+
+// decode reads a published vote into v and returns the trace context it
+// carries. Votes published before they carried trace context are bare
+// ballots, which decode as an event without a vote.
+func decode(data []byte, v *pb.Vote) (map[string]string, error) {
+	var event pb.VoteEvent
+	err := proto.Unmarshal(data, &event)
+	if err == nil && event.GetVote() != nil {
+		*v = *event.GetVote()
+		return event.GetTrace(), nil
+	}
+	return nil, proto.Unmarshal(data, v)
 }
 
-func validateVote(serviceName string, vote *pb.Vote) error {
-	resp, err := http.Get(serviceName + "/election/" + fmt.Sprint(vote.GetElectionId()) + "/validate?candidate=" + vote.GetCandidate())
+// validateVote has the election service check the ballot against the voting
+// method of the election. Voter and code are left out of the request.
+func validateVote(ctx context.Context, serviceName string, vote *pb.Vote) (*pb.Election, error) {
+	b := *vote
+	b.Voter, b.Code = "", ""
+	j, err := json.Marshal(&b)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, serviceName+"/election/"+fmt.Sprint(vote.GetElectionId())+"/validate", bytes.NewReader(j))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := tracing.Client.Do(req)
+	if err != nil {
+		return nil, err
 	}
 
 	defer resp.Body.Close()
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return errors.New(string(body))
+		return nil, errors.New(string(body))
+	}
+
+	var election pb.Election
+	err = json.Unmarshal(body, &election)
+	if err != nil {
+		return nil, err
+	}
+	return &election, nil
+}
+
+// admit consumes the code of invite only elections and records the voter
+// participation, each of them rejects a second ballot
+func (s *spec) admit(election *pb.Election, v *pb.Vote) error {
+	if election.GetInviteOnly() {
+		err := consumeCode(s.mgoDal, s.CodeColl, v)
+		if err != nil || v.GetVoter() == "" {
+			return err
+		}
+	}
+
+	err := participate(s.mgoDal, s.PartColl, v)
+	if err != nil && election.GetInviteOnly() {
+		s.restoreCode(v)
+	}
+	return err
+}
+
+// revoke undoes admit when the ballot could not be stored, so the voter can
+// try again
+func (s *spec) revoke(election *pb.Election, v *pb.Vote) {
+	if election.GetInviteOnly() {
+		s.restoreCode(v)
+	}
+	if v.GetVoter() != "" {
+		err := s.mgoDal.Remove(s.PartColl, bson.M{voteElecIDKey: v.GetElectionId(), voterKey: v.GetVoter()})
+		if err != nil {
+			s.logger.Error(errRollback, zap.Error(err), zap.Int32("electionId", v.GetElectionId()))
+		}
+	}
+}
+
+func (s *spec) restoreCode(v *pb.Vote) {
+	err := s.mgoDal.Update(s.CodeColl, codeSelector(v, true), bson.M{"$set": bson.M{codeUsedKey: false}})
+	if err != nil {
+		s.logger.Error(errRollback, zap.Error(err), zap.Int32("electionId", v.GetElectionId()))
+	}
+}
+
+// consumeCode marks the code of the vote as used. The update only matches an
+// unused code, so concurrent processors cannot consume it twice.
+func consumeCode(dal db.DataAccessLayer, coll string, vote *pb.Vote) error {
+	if vote.GetCode() == "" {
+		return errors.New(errNoCode)
+	}
+	err := dal.Update(coll, codeSelector(vote, false), bson.M{"$set": bson.M{codeUsedKey: true}})
+	if err == mgo.ErrNotFound {
+		return errors.New(errInvalidCode)
+	}
+	return err
+}
+
+func codeSelector(vote *pb.Vote, used bool) bson.M {
+	return bson.M{voteElecIDKey: vote.GetElectionId(), codeHashKey: invite.Hash(vote.GetCode()), codeUsedKey: used}
+}
+
+// participate records that the voter took part in the election. The unique
+// index on election and voter rejects a second vote. Only the day is kept, so
+// the record can not be matched with a ballot by the time it was cast.
+func participate(dal db.DataAccessLayer, coll string, vote *pb.Vote) error {
+	if vote.GetVoter() == "" {
+		return errors.New(errNoVoter)
+	}
+	err := dal.Insert(coll, &pb.Participation{
+		ElectionId: vote.GetElectionId(),
+		Voter:      vote.GetVoter(),
+		Time:       &timestamp.Timestamp{Seconds: time.Now().Truncate(24 * time.Hour).Unix()},
+	})
+	if mgo.IsDup(err) {
+		return errors.New(errAlreadyVoted)
+	}
+	return err
+}
+
+// stamp sets the time the ballot is stored on elections breaking ties by
+// earliest vote and clears it otherwise, as it ties the ballot to the moment
+// the voter took part
+func stamp(election *pb.Election, vote *pb.Vote) {
+	vote.Cast = nil
+	if election.GetTieBreak() == pb.TieBreak_EARLIEST {
+		vote.Cast = ptypes.TimestampNow()
+	}
+}
+
+// weigh sets the ballot weight to the imported weight of its voter on
+// weighted elections and clears it otherwise
+func weigh(dal db.DataAccessLayer, coll string, election *pb.Election, vote *pb.Vote) error {
+	vote.Weight = 0
+	if !election.GetWeighted() {
+		return nil
+	}
+	if vote.GetVoter() == "" {
+		return errors.New(errNoVoter)
 	}
 
+	var w pb.VoterWeight
+	err := dal.FindOne(coll, bson.M{voteElecIDKey: vote.GetElectionId(), voterKey: vote.GetVoter()}, &w)
+	if err == mgo.ErrNotFound {
+		return errors.New(errNoWeight)
+	}
+	if err != nil {
+		return err
+	}
+	vote.Weight = w.GetWeight()
 	return nil
 }
 
+// ballot is the stored form of a vote. It has neither voter nor code and is
+// keyed by the random receipt instead of an object id holding its insertion
+// time. It is still written right after the participation record, so the
+// order of writes in the mongo oplog can link the two.
+type ballot struct {
+	ID      string `bson:"_id"`
+	pb.Vote `bson:",inline"`
+}
+
 func vote(dal db.DataAccessLayer, coll string, vote *pb.Vote) error {
-	return dal.Insert(coll, &vote)
+	if vote.GetReceipt() == "" {
+		return errors.New(errNoReceipt)
+	}
+	b := ballot{ID: vote.GetReceipt(), Vote: *vote}
+	b.Voter = ""
+	b.Code = ""
+	return dal.Insert(coll, &b)
 }
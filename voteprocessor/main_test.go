@@ -1,17 +1,29 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ednesic/vote-test/db"
+	"github.com/ednesic/vote-test/health"
+	"github.com/ednesic/vote-test/invite"
 	"github.com/ednesic/vote-test/pb"
 	"github.com/ednesic/vote-test/tests"
+	"github.com/ednesic/vote-test/tracing"
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
 	gock "gopkg.in/h2non/gock.v1"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/dbtest"
 )
 
@@ -20,6 +32,8 @@ var Server dbtest.DBServer
 func Test_getElectionEnd(t *testing.T) {
 	const server = "http://localhost"
 	defer gock.Off()
+	gock.InterceptClient(tracing.Client)
+	defer gock.RestoreClient(tracing.Client)
 
 	tests := []struct {
 		name       string
@@ -38,25 +52,59 @@ func Test_getElectionEnd(t *testing.T) {
 
 			if tt.errorReply != nil {
 				gock.New(server).
-					Get("/election/"+fmt.Sprint(tt.id)+"/validate").
-					MatchParam("candidate", tt.candidate).
+					Post("/election/" + fmt.Sprint(tt.id) + "/validate").
+					JSON(pb.Vote{Candidate: tt.candidate, ElectionId: tt.id}).
 					ReplyError(tt.errorReply)
 			} else {
 				gock.New(server).
-					Get("/election/"+fmt.Sprint(tt.id)+"/validate").
-					MatchParam("candidate", tt.candidate).
-					Reply(tt.reply)
+					Post("/election/" + fmt.Sprint(tt.id) + "/validate").
+					JSON(pb.Vote{Candidate: tt.candidate, ElectionId: tt.id}).
+					Reply(tt.reply).
+					JSON(pb.Election{Id: tt.id, Candidates: []string{tt.candidate}})
 			}
-			err := validateVote(server, &pb.Vote{Candidate: tt.candidate, ElectionId: tt.id})
+			election, err := validateVote(context.Background(), server, &pb.Vote{Candidate: tt.candidate, ElectionId: tt.id, Voter: "voterMock"})
 			if tt.wantErr {
 				assert.NotNil(t, err)
 			} else {
 				assert.Nil(t, err)
+				assert.Equal(t, tt.id, election.GetId())
 			}
 		})
 	}
 }
 
+func Test_decode(t *testing.T) {
+	ballot := &pb.Vote{ElectionId: 1, Candidate: "test1", Receipt: "r1"}
+	trace := map[string]string{"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}
+	event, _ := proto.Marshal(&pb.VoteEvent{Vote: ballot, Trace: trace})
+	bare, _ := proto.Marshal(ballot)
+
+	tests := []struct {
+		name      string
+		data      []byte
+		wantTrace map[string]string
+		wantErr   bool
+	}{
+		{"Vote event", event, trace, false},
+		{"Bare ballot", bare, nil, false},
+		{"Invalid data", []byte{0xff}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v pb.Vote
+			got, err := decode(tt.data, &v)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantTrace, got)
+			assert.Equal(t, ballot.GetCandidate(), v.GetCandidate())
+			assert.Equal(t, ballot.GetReceipt(), v.GetReceipt())
+		})
+	}
+}
+
 func Test_vote(t *testing.T) {
 	mgoDal := &tests.DataAccessLayerMock{}
 	type args struct {
@@ -70,15 +118,240 @@ func Test_vote(t *testing.T) {
 		wantErr  bool
 		queryRet error
 	}{
-		{"Insert work", args{dal: mgoDal, coll: "test", vote: &pb.Vote{}}, false, nil},
-		{"Insert fail", args{dal: mgoDal, coll: "test", vote: &pb.Vote{}}, true, errors.New("err")},
+		{"Insert work", args{dal: mgoDal, coll: "test", vote: &pb.Vote{Receipt: "r", Voter: "v", Code: "c"}}, false, nil},
+		{"Insert fail", args{dal: mgoDal, coll: "test", vote: &pb.Vote{Receipt: "r", Voter: "v"}}, true, errors.New("err")},
+		{"Missing receipt", args{dal: mgoDal, coll: "test", vote: &pb.Vote{Voter: "v"}}, true, nil},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mgoDal.On("Insert", mock.Anything, mock.Anything, mock.Anything).Return(tt.queryRet).Once()
+			mgoDal.ExpectedCalls = nil
+			mgoDal.On("Insert", mock.Anything, mock.Anything).Return(tt.queryRet).Run(func(args mock.Arguments) {
+				stored := args.Get(1).(*ballot)
+				assert.Equal(t, tt.args.vote.GetReceipt(), stored.ID)
+				assert.Empty(t, stored.GetVoter(), "ballot must not be stored with its voter")
+				assert.Empty(t, stored.GetCode(), "ballot must not be stored with its code")
+			}).Once()
 			if err := vote(tt.args.dal, tt.args.coll, tt.args.vote); (err != nil) != tt.wantErr {
 				t.Errorf("vote() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			assert.Equal(t, "v", tt.args.vote.GetVoter(), "vote must not be modified")
+		})
+	}
+}
+
+func Test_participate(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	tests := []struct {
+		name     string
+		vote     *pb.Vote
+		queryRet error
+		wantErr  bool
+	}{
+		{"First vote", &pb.Vote{ElectionId: 1, Voter: "v"}, nil, false},
+		{"Already voted", &pb.Vote{ElectionId: 1, Voter: "v"}, &mgo.LastError{Code: 11000}, true},
+		{"Insert fail", &pb.Vote{ElectionId: 1, Voter: "v"}, errors.New("err"), true},
+		{"Missing voter", &pb.Vote{ElectionId: 1}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			mgoDal.On("Insert", "participation", mock.Anything).Return(tt.queryRet).Run(func(args mock.Arguments) {
+				p := args.Get(1).(*pb.Participation)
+				assert.Equal(t, tt.vote.GetVoter(), p.GetVoter())
+				assert.Zero(t, p.GetTime().GetSeconds()%(24*60*60))
+			}).Once()
+			if err := participate(mgoDal, "participation", tt.vote); (err != nil) != tt.wantErr {
+				t.Errorf("participate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_weigh(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	weighted := &pb.Election{Id: 1, Weighted: true}
+	tests := []struct {
+		name       string
+		election   *pb.Election
+		vote       *pb.Vote
+		queryRet   error
+		wantWeight int64
+		wantErr    bool
+	}{
+		{"Weighted voter", weighted, &pb.Vote{ElectionId: 1, Voter: "v"}, nil, 100, false},
+		{"Unweighted election", &pb.Election{Id: 1}, &pb.Vote{ElectionId: 1, Voter: "v", Weight: 5}, nil, 0, false},
+		{"Voter without weight", weighted, &pb.Vote{ElectionId: 1, Voter: "v"}, mgo.ErrNotFound, 0, true},
+		{"Find fail", weighted, &pb.Vote{ElectionId: 1, Voter: "v"}, errors.New("err"), 0, true},
+		{"Missing voter", weighted, &pb.Vote{ElectionId: 1, Code: "c"}, nil, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			mgoDal.On("FindOne", "weight", mock.Anything, mock.Anything).Return(tt.queryRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*pb.VoterWeight) = pb.VoterWeight{ElectionId: 1, Voter: "v", Weight: 100}
+			}).Once()
+			if err := weigh(mgoDal, "weight", tt.election, tt.vote); (err != nil) != tt.wantErr {
+				t.Errorf("weigh() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.wantWeight, tt.vote.GetWeight())
+			}
+		})
+	}
+}
+
+func Test_stamp(t *testing.T) {
+	v := &pb.Vote{ElectionId: 1, Candidate: "a", Cast: ptypes.TimestampNow()}
+	stamp(&pb.Election{Id: 1}, v)
+	assert.Nil(t, v.GetCast())
+
+	stamp(&pb.Election{Id: 1, TieBreak: pb.TieBreak_EARLIEST}, v)
+	assert.NotNil(t, v.GetCast())
+}
+
+func Test_consumeCode(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	tests := []struct {
+		name     string
+		vote     *pb.Vote
+		queryRet error
+		wantErr  bool
+	}{
+		{"Valid code", &pb.Vote{ElectionId: 1, Code: "abcd"}, nil, false},
+		{"Used or unknown code", &pb.Vote{ElectionId: 1, Code: "abcd"}, mgo.ErrNotFound, true},
+		{"Update fail", &pb.Vote{ElectionId: 1, Code: "abcd"}, errors.New("err"), true},
+		{"Missing code", &pb.Vote{ElectionId: 1}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			mgoDal.On("Update", "code", mock.Anything, mock.Anything).Return(tt.queryRet).Run(func(args mock.Arguments) {
+				selector := args.Get(1).(bson.M)
+				assert.Equal(t, invite.Hash("ABCD"), selector[codeHashKey])
+				assert.Equal(t, false, selector[codeUsedKey])
+			}).Once()
+			if err := consumeCode(mgoDal, "code", tt.vote); (err != nil) != tt.wantErr {
+				t.Errorf("consumeCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_spec_admit(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	log, _ := zap.NewProduction()
+	open := &pb.Election{Id: 1}
+	inviteOnly := &pb.Election{Id: 1, InviteOnly: true}
+
+	tests := []struct {
+		name       string
+		election   *pb.Election
+		vote       *pb.Vote
+		consumeRet error
+		insertRet  error
+		restored   bool
+		wantErr    bool
+	}{
+		{"Open election", open, &pb.Vote{ElectionId: 1, Voter: "v"}, nil, nil, false, false},
+		{"Open election voted twice", open, &pb.Vote{ElectionId: 1, Voter: "v"}, nil, &mgo.LastError{Code: 11000}, false, true},
+		{"Code without voter", inviteOnly, &pb.Vote{ElectionId: 1, Code: "c"}, nil, nil, false, false},
+		{"Code with voter", inviteOnly, &pb.Vote{ElectionId: 1, Code: "c", Voter: "v"}, nil, nil, false, false},
+		{"Invalid code", inviteOnly, &pb.Vote{ElectionId: 1, Code: "c", Voter: "v"}, mgo.ErrNotFound, nil, false, true},
+		{"Code restored when voter voted twice", inviteOnly, &pb.Vote{ElectionId: 1, Code: "c", Voter: "v"}, nil, &mgo.LastError{Code: 11000}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			mgoDal.Calls = nil
+			s := &spec{PartColl: "participation", CodeColl: "code", mgoDal: mgoDal, logger: log}
+
+			mgoDal.On("Update", "code", mock.Anything, bson.M{"$set": bson.M{codeUsedKey: true}}).Return(tt.consumeRet).Once()
+			mgoDal.On("Update", "code", mock.Anything, bson.M{"$set": bson.M{codeUsedKey: false}}).Return(nil).Once()
+			mgoDal.On("Insert", "participation", mock.Anything).Return(tt.insertRet).Once()
+
+			if err := s.admit(tt.election, tt.vote); (err != nil) != tt.wantErr {
+				t.Errorf("spec.admit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			restored := false
+			for _, c := range mgoDal.Calls {
+				if c.Method == "Update" && c.Arguments.Get(2).(bson.M)["$set"].(bson.M)[codeUsedKey] == false {
+					restored = true
+				}
+			}
+			assert.Equal(t, tt.restored, restored)
+		})
+	}
+}
+
+func Test_spec_shutdown(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	stanMock := new(tests.StanConnMock)
+	sub := &tests.SubscriptionMock{}
+
+	tests := []struct {
+		name     string
+		vote     time.Duration
+		unsubRet error
+		wantErr  error
+	}{
+		{"Waits for votes in flight", 20 * time.Millisecond, nil, nil},
+		{"Vote past the timeout", time.Second, nil, context.DeadlineExceeded},
+		{"Unsubscribe fail", 0, errors.New("test error"), errors.New("test error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			mgoDal.Calls = nil
+			mgoDal.On("Close").Return()
+			sub.ExpectedCalls = nil
+			sub.On("Unsubscribe").Return(tt.unsubRet)
+			s := &spec{ShutdownTimeout: 100 * time.Millisecond, mgoDal: mgoDal}
+
+			s.inFlight.Add(1)
+			go func() {
+				defer s.inFlight.Done()
+				time.Sleep(tt.vote)
+			}()
+
+			assert.Equal(t, tt.wantErr, s.shutdown(&http.Server{}, sub, stanMock))
+			sub.AssertCalled(t, "Unsubscribe")
+			mgoDal.AssertCalled(t, "Close")
+		})
+	}
+}
+
+func Test_spec_ready(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	stanMock := new(tests.StanConnMock)
+	log, _ := zap.NewProduction()
+
+	tests := []struct {
+		name       string
+		pingRet    error
+		statusCode int
+		want       []health.Dependency
+	}{
+		{"Mongo up", nil, http.StatusServiceUnavailable, []health.Dependency{{Name: "mongo", Status: "ok"}, {Name: "nats", Status: "down", Error: health.ErrNatsDown.Error()}}},
+		{"Mongo down", errors.New("test error"), http.StatusServiceUnavailable, []health.Dependency{{Name: "mongo", Status: "down", Error: "test error"}, {Name: "nats", Status: "down", Error: health.ErrNatsDown.Error()}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			mgoDal.On("Ping").Return(tt.pingRet)
+			s := &spec{mgoDal: mgoDal, logger: log}
+
+			req, err := http.NewRequest("GET", "http://localhost:9224/readyz", nil)
+			assert.Nil(t, err, "could not create request")
+			rec := httptest.NewRecorder()
+
+			s.healthRoutes(stanMock).ServeHTTP(rec, req)
+			res := rec.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.statusCode, res.StatusCode, "Did not get the same response code")
+			var report health.Report
+			assert.Nil(t, json.NewDecoder(res.Body).Decode(&report))
+			assert.Equal(t, tt.want, report.Dependencies)
 		})
 	}
 }
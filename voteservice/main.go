@@ -1,31 +1,67 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/ednesic/vote-test/ballot"
+	"github.com/ednesic/vote-test/db"
+	"github.com/ednesic/vote-test/health"
+	"github.com/ednesic/vote-test/merkle"
+	"github.com/ednesic/vote-test/metrics"
 	"github.com/ednesic/vote-test/pb"
+	"github.com/ednesic/vote-test/tracing"
 	"github.com/gogo/protobuf/proto"
 	"github.com/gorilla/mux"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/nats-io/go-nats-streaming"
 	"github.com/nats-io/nuid"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
 )
 
 const (
-	errEnvVarFail  = `Failed to get environment variables:`
-	errConnLost    = `Connection lost:`
-	errConnFailed  = `Connection failed`
-	errFailPubVote = `Failed to publish vote`
-	errInvalidData = `Invalid Vote Data`
-	errInvalidID   = `Invalid Id`
-	errInvalidUser = `Invalid User`
-	errInterrupt   = `Shutting down`
+	errEnvVarFail   = `Failed to get environment variables:`
+	errConnLost     = `Connection lost:`
+	errConnFailed   = `Connection failed`
+	errFailPubVote  = `Failed to publish vote`
+	errInvalidData  = `Invalid Vote Data`
+	errInvalidID    = `Invalid Id`
+	errInvalidUser  = `Invalid User`
+	errInvalidVoter = `Invalid Voter`
+	errInterrupt    = `Shutting down`
+	errListen       = `HTTP server failed`
+	errShutdown     = `Failed to shut down cleanly`
+	errTracing      = `Failed to set up tracing`
+	errReceipt      = `Failed to create receipt`
+	errNotFound     = `Not found vote`
+	errNotCommitted = `Ballots not committed yet`
+	errRetrieve     = `Failed to retrieve query`
+	errProof        = `Failed to build proof`
+	errRootMismatch = `Ballots do not match the committed root`
 
 	listenMsg     = "HTTP Sever listening"
 	voteCreateMsg = "POST vote creation"
+	voteProofMsg  = "GET vote proof"
+	mongoName     = "mongo"
+	natsName      = "nats"
+	traceName     = "vote-service"
+	pingTimeout   = 2 * time.Second
+
+	receiptKey    = "receipt"
+	idKey         = "_id"
+	voteElecIDKey = "electionid"
 )
 
 type server struct {
@@ -34,15 +70,28 @@ type server struct {
 	VoteChannel   string `envconfig:"VOTE_CHANNEL" default:"create-vote"`
 	NatsServer    string `envconfig:"NATS_SERVER" default:"localhost:4222"`
 	ClientID      string `envconfig:"CLIENT_ID" default:"vote-service"`
+	MgoURL        string `envconfig:"MONGO_URL" default:"localhost:27017"`
+	Database      string `envconfig:"DATABASE" default:"elections"`
+	Collection    string `envconfig:"COLLECTION" default:"vote"`
+	CommitColl    string `envconfig:"COMMITMENT_COLLECTION" default:"commitment"`
+	ProofColl     string `envconfig:"PROOF_COLLECTION" default:"proof"`
+
+	// ShutdownTimeout bounds how long shutdown waits for requests in flight
+	ShutdownTimeout time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"30s"`
+
+	newReceipt func() (string, error)
 
 	logger   *zap.Logger
 	srv      *http.Server
 	stanConn stan.Conn
+	mgoDal   db.DataAccessLayer
 }
 
 func (s *server) run() {
 	var err error
 
+	s.newReceipt = newReceipt
+
 	s.logger, err = zap.NewProduction()
 	if err != nil {
 		log.Fatal(err)
@@ -53,9 +102,14 @@ func (s *server) run() {
 		s.logger.Fatal(errEnvVarFail, zap.Error(err))
 	}
 
+	stopTracing, err := tracing.Init(context.Background(), traceName)
+	if err != nil {
+		s.logger.Fatal(errTracing, zap.Error(err))
+	}
+
 	srv := &http.Server{
 		Addr:    ":" + s.Port,
-		Handler: s.initRoutes(),
+		Handler: tracing.Handler(s.initRoutes()),
 	}
 
 	s.stanConn, err = stan.Connect(
@@ -71,18 +125,68 @@ func (s *server) run() {
 		s.logger.Fatal(errConnFailed, zap.Error(err))
 	}
 
+	s.mgoDal, err = db.NewMongoDAL(s.MgoURL, s.Database)
+	if err != nil {
+		s.logger.Fatal(errConnFailed, zap.Error(err))
+	}
+	s.mgoDal = metrics.DAL(s.mgoDal)
+
 	defer s.logger.Sync()
+	go func() {
+		s.logger.Info(listenMsg, zap.String("Port", s.Port))
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			s.logger.Fatal(errListen, zap.Error(err))
+		}
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	s.logger.Info(errInterrupt, zap.Stringer("Signal", <-signals))
+
+	err = s.shutdown(srv)
+	if err != nil {
+		s.logger.Error(errShutdown, zap.Error(err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+	defer cancel()
+	err = stopTracing(ctx)
+	if err != nil {
+		s.logger.Error(errShutdown, zap.Error(err))
+	}
+}
+
+// shutdown stops accepting requests and waits up to the shutdown timeout for
+// the requests in flight before closing the nats connection and the mongo
+// session
+func (s *server) shutdown(srv *http.Server) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+	defer cancel()
+	defer s.mgoDal.Close()
 	defer s.stanConn.Close()
-	s.logger.Info(listenMsg, zap.String("Port", s.Port))
-	s.logger.Fatal(errInterrupt, zap.Error(srv.ListenAndServe()))
+
+	return srv.Shutdown(ctx)
 }
 
 func (s *server) initRoutes() *mux.Router {
 	router := mux.NewRouter()
 	router.HandleFunc("/vote", s.createVote).Methods(http.MethodPost)
+	router.HandleFunc("/vote/{"+receiptKey+"}/proof", s.proof).Methods(http.MethodGet)
+	router.HandleFunc(health.LivePath, health.Live).Methods(http.MethodGet)
+	router.HandleFunc(health.ReadyPath, health.Ready(s.checks(), pingTimeout)).Methods(http.MethodGet)
+	router.Handle(metrics.Path, metrics.Handler()).Methods(http.MethodGet)
+	router.Use(metrics.Middleware)
 	return router
 }
 
+// checks are the dependencies the service needs to be ready
+func (s *server) checks() map[string]health.Check {
+	return map[string]health.Check{
+		mongoName: func() error { return s.mgoDal.Ping() },
+		natsName:  func() error { return health.Nats(s.stanConn) },
+	}
+}
+
 func (s *server) createVote(w http.ResponseWriter, r *http.Request) {
 	var (
 		err     error
@@ -90,7 +194,7 @@ func (s *server) createVote(w http.ResponseWriter, r *http.Request) {
 		stsCode = http.StatusCreated
 	)
 	defer func() {
-		defer s.logger.Info(voteCreateMsg, zap.Error(err), zap.Int32("electionId", vote.GetElectionId()), zap.String("User", vote.GetCandidate()), zap.Int("StatusCode", stsCode))
+		defer s.logger.Info(voteCreateMsg, zap.Error(err), zap.Int32("electionId", vote.GetElectionId()), zap.Int("StatusCode", stsCode))
 	}()
 
 	err = json.NewDecoder(r.Body).Decode(&vote)
@@ -104,30 +208,147 @@ func (s *server) createVote(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, errInvalidID, stsCode)
 		return
 	}
-	if vote.GetCandidate() == "" {
+	err = ballot.Check(&vote)
+	if err == ballot.ErrEmpty {
 		stsCode = http.StatusBadRequest
 		http.Error(w, errInvalidUser, stsCode)
 		return
 	}
+	if err != nil {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errInvalidData, stsCode)
+		return
+	}
+	if vote.GetVoter() == "" && vote.GetCode() == "" {
+		stsCode = http.StatusBadRequest
+		http.Error(w, errInvalidVoter, stsCode)
+		return
+	}
+
+	vote.Receipt, err = s.newReceipt()
+	if err != nil {
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errReceipt, stsCode)
+		return
+	}
 
-	err = s.publishEvent(&vote)
+	err = s.publishEvent(r.Context(), &vote)
 	if err != nil {
 		stsCode = http.StatusInternalServerError
 		http.Error(w, errFailPubVote, stsCode)
 		return
 	}
+	metrics.VotesPublished.Inc()
 
 	w.WriteHeader(stsCode)
 	j, _ := json.Marshal(vote)
 	w.Write(j)
 }
 
-func (s *server) publishEvent(vote *pb.Vote) error {
-	voteJSON, err := proto.Marshal(vote)
+// proof returns the inclusion path of a ballot stored when the election service
+// committed the ballots of the closed election
+func (s *server) proof(w http.ResponseWriter, r *http.Request) {
+	var (
+		err        error
+		vote       pb.Vote
+		commitment pb.Commitment
+		p          pb.InclusionProof
+		path       []merkle.Step
+		leaf       []byte
+		root       []byte
+		stsCode    = http.StatusOK
+		receipt    = mux.Vars(r)[receiptKey]
+	)
+	defer func() {
+		defer s.logger.Info(voteProofMsg, zap.Error(err), zap.Int32("electionId", vote.GetElectionId()), zap.String("Receipt", receipt), zap.Int("StatusCode", stsCode))
+	}()
+
+	// the processor stores every ballot under its receipt
+	err = s.mgoDal.FindOne(s.Collection, bson.M{idKey: receipt}, &vote)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			stsCode = http.StatusNotFound
+			http.Error(w, errNotFound, stsCode)
+			return
+		}
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errRetrieve, stsCode)
+		return
+	}
+
+	err = s.mgoDal.FindOne(s.ProofColl, bson.M{receiptKey: receipt}, &p)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			stsCode = http.StatusConflict
+			http.Error(w, errNotCommitted, stsCode)
+			return
+		}
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errRetrieve, stsCode)
+		return
+	}
+
+	err = s.mgoDal.FindOne(s.CommitColl, bson.M{voteElecIDKey: vote.GetElectionId()}, &commitment)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			stsCode = http.StatusConflict
+			http.Error(w, errNotCommitted, stsCode)
+			return
+		}
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errRetrieve, stsCode)
+		return
+	}
+
+	leaf, err = merkle.BallotLeaf(&vote)
+	if err != nil {
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errProof, stsCode)
+		return
+	}
+
+	// the stored proof must still lead from this ballot to the published root
+	root, _ = hex.DecodeString(commitment.GetRoot())
+	for _, step := range p.GetPath() {
+		h, _ := hex.DecodeString(step.GetHash())
+		path = append(path, merkle.Step{Hash: h, Left: step.GetLeft()})
+	}
+	if hex.EncodeToString(leaf) != p.GetBallot() || p.GetRoot() != commitment.GetRoot() || !merkle.Verify(leaf, path, root) {
+		err = errors.New(errRootMismatch)
+		stsCode = http.StatusInternalServerError
+		http.Error(w, errRootMismatch, stsCode)
+		return
+	}
+
+	w.WriteHeader(stsCode)
+	j, _ := json.Marshal(p)
+	w.Write(j)
+}
+
+// publishEvent publishes the vote for the processor along with the trace
+// context of ctx, as nats streaming messages have no headers to carry it
+func (s *server) publishEvent(ctx context.Context, vote *pb.Vote) (err error) {
+	if vote == nil {
+		return errors.New(errInvalidData)
+	}
+	ctx, span := tracing.Start(ctx, "publish "+s.VoteChannel, trace.SpanKindProducer)
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	event, err := proto.Marshal(&pb.VoteEvent{Vote: vote, Trace: tracing.Inject(ctx)})
 	if err != nil {
 		return err
 	}
-	return s.stanConn.Publish(s.VoteChannel, voteJSON)
+	return s.stanConn.Publish(s.VoteChannel, event)
+}
+
+func newReceipt() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
 }
 
 func main() {
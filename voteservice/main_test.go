@@ -1,17 +1,28 @@
 package main
 
 import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/ednesic/vote-test/health"
+	"github.com/ednesic/vote-test/merkle"
+	"github.com/ednesic/vote-test/metrics"
 	"github.com/ednesic/vote-test/pb"
 	"github.com/ednesic/vote-test/tests"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
+	mgo "gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
 )
 
 func Test_server_publishEvent(t *testing.T) {
@@ -38,7 +49,7 @@ func Test_server_publishEvent(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &server{stanConn: stanMock, logger: log}
-			if err := s.publishEvent(tt.args.vote); (err != nil) != tt.wantErr {
+			if err := s.publishEvent(context.Background(), tt.args.vote); (err != nil) != tt.wantErr {
 				t.Errorf("server.publishEvent() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -47,6 +58,9 @@ func Test_server_publishEvent(t *testing.T) {
 
 func Test_server_createVote(t *testing.T) {
 	log, _ := zap.NewProduction()
+	newReceiptMock := func() (string, error) {
+		return "receiptMock", nil
+	}
 
 	stanMock := new(tests.StanConnMock)
 	tests := []struct {
@@ -56,25 +70,38 @@ func Test_server_createVote(t *testing.T) {
 		responseBody string
 		pubRes       error
 	}{
-		{"Could not process message", `{"electionId":12,"candidate":"abc"}`, http.StatusInternalServerError, errFailPubVote, errors.New("err")},
-		{"Creation successful", `{"electionId":12,"candidate":"abc"}`, http.StatusCreated, `{"ElectionId":12,"candidate":"abc"}`, nil},
+		{"Could not process message", `{"electionId":12,"candidate":"abc","voter":"v1"}`, http.StatusInternalServerError, errFailPubVote, errors.New("err")},
+		{"Creation successful", `{"electionId":12,"candidate":"abc","voter":"v1"}`, http.StatusCreated, `{"ElectionId":12,"candidate":"abc","receipt":"receiptMock","voter":"v1"}`, nil},
+		{"Code instead of voter", `{"electionId":12,"candidate":"abc","code":"c1"}`, http.StatusCreated, `{"ElectionId":12,"candidate":"abc","receipt":"receiptMock","code":"c1"}`, nil},
+		{"Missing voter and code", `{"electionId":12,"candidate":"abc"}`, http.StatusBadRequest, errInvalidVoter, nil},
 		{"Wrong user type", `{"electionId":"12"}`, http.StatusBadRequest, errInvalidData, nil},
 		{"Wrong id type", `{"candidate":12}`, http.StatusBadRequest, errInvalidData, nil},
 		{"Missing user", `{"electionId":12}`, http.StatusBadRequest, errInvalidUser, nil},
 		{"Missing id", `{"candidate":"abc"}`, http.StatusBadRequest, errInvalidID, nil},
 		{"Missing all", `{}`, http.StatusBadRequest, errInvalidID, nil},
+		{"Encrypted ballot", `{"electionId":12,"voter":"v1","encrypted":[{"a":"Ag==","b":"Ag=="}]}`, http.StatusCreated, `{"ElectionId":12,"receipt":"receiptMock","encrypted":[{"a":"Ag==","b":"Ag=="}],"voter":"v1"}`, nil},
+		{"Candidate and encrypted ballot", `{"electionId":12,"candidate":"abc","encrypted":[{"a":"Ag==","b":"Ag=="}]}`, http.StatusBadRequest, errInvalidData, nil},
+		{"Ranked ballot", `{"electionId":12,"voter":"v1","rankings":["b","a"]}`, http.StatusCreated, `{"ElectionId":12,"receipt":"receiptMock","voter":"v1","rankings":["b","a"]}`, nil},
+		{"Referendum ballot", `{"electionId":12,"voter":"v1","answers":[{"contest":"q1","candidate":"yes"}]}`, http.StatusCreated, `{"ElectionId":12,"receipt":"receiptMock","voter":"v1","answers":[{"contest":"q1","candidate":"yes"}]}`, nil},
+		{"Blank ballot", `{"electionId":12,"voter":"v1","abstain":true}`, http.StatusCreated, `{"ElectionId":12,"receipt":"receiptMock","voter":"v1","abstain":true}`, nil},
+		{"Selections and rankings", `{"electionId":12,"voter":"v1","selections":["a"],"rankings":["b","a"]}`, http.StatusBadRequest, errInvalidData, nil},
 		{"Wrong parameters", `{"electionId":12,"candidate":"abc","Home: 5"}`, http.StatusBadRequest, errInvalidData, nil},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			stanMock.On("Publish", mock.Anything, mock.Anything).Return(tt.pubRes).Once()
 			s := &server{
-				stanConn: stanMock,
-				logger:   log,
+				stanConn:   stanMock,
+				logger:     log,
+				newReceipt: newReceiptMock,
 			}
 			req, err := http.NewRequest("POST", "localhost:9222/vote", strings.NewReader(tt.body))
 			assert.Nil(t, err, "could not create request")
 
+			published := testutil.ToFloat64(metrics.VotesPublished)
+			if tt.statusCode == http.StatusCreated {
+				published++
+			}
 			rec := httptest.NewRecorder()
 			s.createVote(rec, req)
 			res := rec.Result()
@@ -82,10 +109,105 @@ func Test_server_createVote(t *testing.T) {
 
 			assert.Equal(t, tt.statusCode, res.StatusCode, "Did not get the same response code")
 			assert.Equal(t, tt.responseBody, strings.TrimSuffix(rec.Body.String(), "\n"))
+			assert.Equal(t, published, testutil.ToFloat64(metrics.VotesPublished))
 		})
 	}
 }
 
+func Test_server_proof(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	log, _ := zap.NewProduction()
+
+	ballots := []pb.Vote{
+		{ElectionId: 1, Candidate: "test1", Receipt: "b"},
+		{ElectionId: 1, Candidate: "test2", Receipt: "a"},
+		{ElectionId: 1, Candidate: "test1", Receipt: "c"},
+	}
+	tree, _ := merkle.Ballots(ballots)
+	root := hex.EncodeToString(tree.Root())
+	path, _ := tree.Proof(1)
+	leaf, _ := merkle.BallotLeaf(&ballots[1])
+	stored := pb.InclusionProof{Receipt: "b", ElectionId: 1, Ballot: hex.EncodeToString(leaf), Index: 1, Root: root}
+	for _, step := range path {
+		stored.Path = append(stored.Path, &pb.InclusionProof_Step{Hash: hex.EncodeToString(step.Hash), Left: step.Left})
+	}
+
+	tests := []struct {
+		name       string
+		candidate  string
+		statusCode int
+		root       string
+		voteRet    error
+		proofRet   error
+		commitRet  error
+	}{
+		{"Proof Ok", "test1", http.StatusOK, root, nil, nil, nil},
+		{"Vote not found", "test1", http.StatusNotFound, root, mgo.ErrNotFound, nil, nil},
+		{"Vote find fail", "test1", http.StatusInternalServerError, root, errors.New("test error"), nil, nil},
+		{"Proof not stored", "test1", http.StatusConflict, root, nil, mgo.ErrNotFound, nil},
+		{"Proof find fail", "test1", http.StatusInternalServerError, root, nil, errors.New("test error"), nil},
+		{"Not committed", "test1", http.StatusConflict, root, nil, nil, mgo.ErrNotFound},
+		{"Commitment find fail", "test1", http.StatusInternalServerError, root, nil, nil, errors.New("test error")},
+		{"Root mismatch", "test1", http.StatusInternalServerError, "ff", nil, nil, nil},
+		{"Ballot changed", "test2", http.StatusInternalServerError, root, nil, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			s := &server{
+				Collection: "vote",
+				CommitColl: "commitment",
+				ProofColl:  "proof",
+				mgoDal:     mgoDal,
+				logger:     log,
+			}
+
+			mgoDal.On("FindOne", "vote", bson.M{"_id": "b"}, mock.Anything).Return(tt.voteRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*pb.Vote) = pb.Vote{ElectionId: 1, Candidate: tt.candidate, Receipt: "b"}
+			}).Once()
+			mgoDal.On("FindOne", "proof", mock.Anything, mock.Anything).Return(tt.proofRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*pb.InclusionProof) = stored
+			}).Once()
+			mgoDal.On("FindOne", "commitment", mock.Anything, mock.Anything).Return(tt.commitRet).Run(func(args mock.Arguments) {
+				*args.Get(2).(*pb.Commitment) = pb.Commitment{ElectionId: 1, Root: tt.root}
+			}).Once()
+
+			req, err := http.NewRequest("GET", "localhost:9222/vote/proof", nil)
+			assert.Nil(t, err, "could not create request")
+			req = mux.SetURLVars(req, map[string]string{"receipt": "b"})
+
+			rec := httptest.NewRecorder()
+			s.proof(rec, req)
+			res := rec.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.statusCode, res.StatusCode, "Did not get the same response code")
+			if tt.statusCode != http.StatusOK {
+				return
+			}
+
+			var p pb.InclusionProof
+			assert.Nil(t, json.NewDecoder(res.Body).Decode(&p))
+			leaf, _ := hex.DecodeString(p.GetBallot())
+			var path []merkle.Step
+			for _, step := range p.GetPath() {
+				h, _ := hex.DecodeString(step.GetHash())
+				path = append(path, merkle.Step{Hash: h, Left: step.GetLeft()})
+			}
+			assert.True(t, merkle.Verify(leaf, path, tree.Root()), "proof does not verify against the root")
+		})
+	}
+}
+
+func Test_newReceipt(t *testing.T) {
+	a, err := newReceipt()
+	assert.Nil(t, err)
+	b, err := newReceipt()
+	assert.Nil(t, err)
+	assert.Len(t, a, 32)
+	assert.NotEqual(t, a, b)
+}
+
 func Test_server_initRoutes(t *testing.T) {
 	tests := []struct {
 		name string
@@ -99,3 +221,73 @@ func Test_server_initRoutes(t *testing.T) {
 		})
 	}
 }
+
+func Test_server_shutdown(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	stanMock := new(tests.StanConnMock)
+
+	tests := []struct {
+		name    string
+		request time.Duration
+		wantErr error
+	}{
+		{"Drains requests in flight", 20 * time.Millisecond, nil},
+		{"Request past the timeout", time.Second, context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			mgoDal.Calls = nil
+			mgoDal.On("Close").Return()
+			s := &server{ShutdownTimeout: 100 * time.Millisecond, mgoDal: mgoDal, stanConn: stanMock}
+
+			started := make(chan struct{})
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(started)
+				time.Sleep(tt.request)
+			}))
+			defer ts.Close()
+			go http.Get(ts.URL)
+			<-started
+
+			assert.Equal(t, tt.wantErr, s.shutdown(ts.Config))
+			mgoDal.AssertCalled(t, "Close")
+		})
+	}
+}
+
+func Test_server_ready(t *testing.T) {
+	mgoDal := &tests.DataAccessLayerMock{}
+	stanMock := new(tests.StanConnMock)
+	log, _ := zap.NewProduction()
+
+	tests := []struct {
+		name       string
+		pingRet    error
+		statusCode int
+		want       []health.Dependency
+	}{
+		{"Mongo up", nil, http.StatusServiceUnavailable, []health.Dependency{{Name: "mongo", Status: "ok"}, {Name: "nats", Status: "down", Error: health.ErrNatsDown.Error()}}},
+		{"Mongo down", errors.New("test error"), http.StatusServiceUnavailable, []health.Dependency{{Name: "mongo", Status: "down", Error: "test error"}, {Name: "nats", Status: "down", Error: health.ErrNatsDown.Error()}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgoDal.ExpectedCalls = nil
+			mgoDal.On("Ping").Return(tt.pingRet)
+			s := &server{mgoDal: mgoDal, stanConn: stanMock, logger: log}
+
+			req, err := http.NewRequest("GET", "http://localhost:9222/readyz", nil)
+			assert.Nil(t, err, "could not create request")
+			rec := httptest.NewRecorder()
+
+			s.initRoutes().ServeHTTP(rec, req)
+			res := rec.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.statusCode, res.StatusCode, "Did not get the same response code")
+			var report health.Report
+			assert.Nil(t, json.NewDecoder(res.Body).Decode(&report))
+			assert.Equal(t, tt.want, report.Dependencies)
+		})
+	}
+}